@@ -5,24 +5,31 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v3"
 
 	"github.com/satococoa/wtp/v2/internal/command"
 	"github.com/satococoa/wtp/v2/internal/config"
 	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/events"
 	"github.com/satococoa/wtp/v2/internal/git"
 	"github.com/satococoa/wtp/v2/internal/hooks"
 	wtpio "github.com/satococoa/wtp/v2/internal/io"
 )
 
+// timingsFileMode is the permission of the file written by --timings.
+const timingsFileMode = 0o600
+
 // NewAddCommand creates the add command definition
 func NewAddCommand() *cli.Command {
 	return &cli.Command{
@@ -30,7 +37,8 @@ func NewAddCommand() *cli.Command {
 		Usage: "Create a new worktree",
 		UsageText: "wtp add <existing-branch>\n" +
 			"       wtp add -b <new-branch> [<commit>]\n" +
-			"       wtp add -b <new-branch> --quiet",
+			"       wtp add -b <new-branch> --quiet\n" +
+			"       wtp add --pr <number> [--remote <remote>]",
 		Description: "Creates a new worktree for the specified branch. If the branch doesn't exist locally " +
 			"but exists on a remote, it will be automatically tracked.\n\n" +
 			"Examples:\n" +
@@ -38,7 +46,15 @@ func NewAddCommand() *cli.Command {
 			"  wtp add -b new-feature                  # Create new branch and worktree\n" +
 			"  wtp add -b hotfix/urgent main           # Create new branch from main commit\n" +
 			"  wtp add -b feature/x --quiet            # Output only the created path\n" +
-			"  wtp add -b feature/x --exec \"npm test\" # Execute command in the new worktree",
+			"  wtp add -b feature/x --exec \"npm test\" # Execute command in the new worktree\n" +
+			"  wtp add -b feature/x --dry-run          # Preview the worktree and hooks\n" +
+			"  wtp add -b review/x --no-hooks          # Create the worktree without running hooks\n" +
+			"  wtp add -b feature/x --skip-hook deps   # Skip the hook with id or name 'deps'\n" +
+			"  wtp add feature/x --submodules init     # Initialize submodules before the hooks run\n" +
+			"  wtp add -b feature/x --sparse web       # Check out only the 'web' sparse profile\n" +
+			"  wtp add --pr 42                         # Review pull request #42 on branch pr/42\n" +
+			"  wtp add -b feature/x --events=json      # Stream NDJSON progress events to stdout\n\n" +
+			"Set WTP_EVENTS_FD to a file descriptor number to receive the same events there instead.",
 		ShellComplete: completeBranches,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -55,6 +71,52 @@ func NewAddCommand() *cli.Command {
 				Aliases: []string{"q"},
 				Usage:   "Output only worktree path to stdout",
 			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the worktree command and resolved hooks without changing anything",
+			},
+			&cli.BoolFlag{
+				Name:  "no-hooks",
+				Usage: "Do not run post-create hooks",
+			},
+			&cli.StringSliceFlag{
+				Name:  "only-hook",
+				Usage: "Run only the given hook id, name or number (repeatable)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "skip-hook",
+				Usage: "Skip the given hook id, name or number (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "trust",
+				Usage: "Run command hooks from .wtp.yml without checking that they were trusted with 'wtp trust'",
+			},
+			&cli.StringFlag{
+				Name:  "timings",
+				Usage: "Write hook timings as JSON to the given file",
+			},
+			&cli.StringFlag{
+				Name: "submodules",
+				Usage: "Initialize submodules in the new worktree: none, init or recursive " +
+					"(default: defaults.submodules in .wtp.yml)",
+			},
+			&cli.StringFlag{
+				Name:  "sparse",
+				Usage: "Check out only the directories of the given sparse_profiles entry in .wtp.yml",
+			},
+			&cli.StringFlag{
+				Name: "pr",
+				Usage: "Fetch the given pull request number into branch pr/<number> and create its worktree " +
+					"(ref: defaults.pr_refspec in .wtp.yml, default refs/pull/{n}/head)",
+			},
+			&cli.StringFlag{
+				Name:  "remote",
+				Usage: "Remote to fetch --pr from (default: defaults.pr_remote in .wtp.yml, else origin)",
+			},
+			&cli.StringFlag{
+				Name:  "events",
+				Usage: "Write progress events to stdout in the given format (json); human output moves to stderr",
+			},
 		},
 		Action: addCommand,
 	}
@@ -103,10 +165,17 @@ func addCommandWithCommandExecutorWithWriters(
 	cfg *config.Config,
 	mainRepoPath string,
 ) error {
-	// Resolve worktree path and branch name
-	var firstArg string
-	if cmd.Args().Len() > 0 {
-		firstArg = cmd.Args().Get(0)
+	emitter, closeEvents, err := resolveAddEvents(cmd, stdoutWriter)
+	if err != nil {
+		return err
+	}
+	defer closeEvents()
+
+	// Resolve worktree path and branch name; --pr stands for its local branch
+	pr := resolveAddPullRequest(cmd, cfg)
+	firstArg := cmd.Args().Get(0)
+	if prBranch := pullRequestBranch(cmd); prBranch != "" {
+		firstArg = prBranch
 	}
 
 	workTreePath, branchName := resolveWorktreePath(cfg, mainRepoPath, firstArg, cmd)
@@ -120,7 +189,60 @@ func addCommandWithCommandExecutorWithWriters(
 	// Build git worktree command using the new command builder
 	worktreeCmd := buildWorktreeCommand(cmd, workTreePath, branchName, resolvedTrack)
 
+	filter, err := resolveAddHookFilter(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	sparseDirs, err := resolveAddSparse(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	hookOpts := hooks.Options{
+		Events: emitter,
+		Filter: filter,
+		Target: addHookTarget(cmd, cfg, mainRepoPath, workTreePath, branchName, resolvedTrack, pr),
+	}
+	if cmd.Bool("dry-run") {
+		return displayAddPlan(stdoutWriter, cfg, mainRepoPath, workTreePath, hookOpts, worktreeCmd,
+			pr, sparseDirs, resolveAddSubmodules(cmd, cfg), cmd.String("exec"))
+	}
+
+	if !cmd.Bool("trust") && selectsCommandHook(cfg, filter) {
+		if err := ensureHooksTrusted(statusWriter, os.Stdin, cfg, mainRepoPath, "wtp add"); err != nil {
+			return err
+		}
+	}
+
+	if err := fetchPullRequest(statusWriter, cmdExec, pr, &hookOpts.Target); err != nil {
+		return err
+	}
+
 	// Execute the command
+	started := time.Now()
+	if err := runWorktreeAdd(cmdExec, worktreeCmd, workTreePath, branchName); err != nil {
+		return err
+	}
+	emitter.Emit(events.Finished(events.Event{
+		Type:     events.WorktreeCreated,
+		Worktree: workTreePath,
+		Branch:   branchName,
+	}, started, nil))
+
+	if err := checkoutSparse(statusWriter, cmdExec, workTreePath, cmd.String("sparse"), sparseDirs); err != nil {
+		return err
+	}
+
+	// Best effort: hooks see an empty GIT_WTP_HEAD when it cannot be resolved.
+	hookOpts.Target.Head, _ = git.HeadCommit(workTreePath)
+	if err := runPostCreateSteps(cmd, statusWriter, cmdExec, cfg, mainRepoPath, workTreePath, hookOpts); err != nil {
+		return err
+	}
+
+	return displayAddResult(cmd, stdoutWriter, statusWriter, cfg, mainRepoPath, workTreePath, branchName)
+}
+
+// runWorktreeAdd runs 'git worktree add', explaining common git errors.
+func runWorktreeAdd(cmdExec command.Executor, worktreeCmd command.Command, workTreePath, branchName string) error {
 	result, err := cmdExec.Execute([]command.Command{worktreeCmd})
 	if err != nil {
 		return err
@@ -134,35 +256,132 @@ func addCommandWithCommandExecutorWithWriters(
 		// Analyze git error output for better error messages
 		return analyzeGitWorktreeError(workTreePath, branchName, gitError, gitOutput)
 	}
+	return nil
+}
+
+// displayAddResult reports the created worktree: only its path with --quiet, a success message otherwise.
+func displayAddResult(
+	cmd *cli.Command,
+	stdoutWriter, statusWriter io.Writer,
+	cfg *config.Config,
+	mainRepoPath, workTreePath, branchName string,
+) error {
+	if cmd.Bool("quiet") {
+		if _, err := fmt.Fprintln(stdoutWriter, workTreePath); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	// With --events=json, stdout carries only events.
+	successWriter := stdoutWriter
+	if cmd.String("events") != "" {
+		successWriter = statusWriter
+	}
+	if err := displaySuccessMessage(successWriter, branchName, workTreePath, cfg, mainRepoPath); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// runPostCreateSteps initializes submodules, then runs the post-create hooks and the
+// --exec command in a new worktree.
+func runPostCreateSteps(
+	cmd *cli.Command,
+	statusWriter io.Writer,
+	cmdExec command.Executor,
+	cfg *config.Config,
+	mainRepoPath, workTreePath string,
+	hookOpts hooks.Options,
+) error {
+	if err := initSubmodules(statusWriter, cmdExec, resolveAddSubmodules(cmd, cfg), workTreePath); err != nil {
+		return fmt.Errorf("worktree was created at '%s', but submodule initialization failed: %w", workTreePath, err)
+	}
 
-	if err := executePostCreateHooks(statusWriter, cfg, mainRepoPath, workTreePath); err != nil {
+	run, err := executePostCreateHooksWithOptions(statusWriter, cfg, mainRepoPath, workTreePath, hookOpts)
+	if timingsPath := cmd.String("timings"); timingsPath != "" {
+		var reports []hooks.TimingReport
+		if run.report != nil {
+			reports = append(reports, *run.report)
+		}
+		if writeErr := writeTimingReports(timingsPath, reports); writeErr != nil {
+			return writeErr
+		}
+	}
+	if err != nil {
 		if _, warnErr := fmt.Fprintf(statusWriter, "Warning: Hook execution failed: %v\n", err); warnErr != nil {
 			return warnErr
 		}
 	}
 
-	if err := executePostCreateCommand(
-		statusWriter,
-		cmdExec,
-		cmd.String("exec"),
-		workTreePath,
-		!cmd.Bool("quiet"),
-	); err != nil {
+	execCommand := cmd.String("exec")
+	if strings.TrimSpace(execCommand) == "" {
+		return nil
+	}
+
+	// Interactive commands write straight to the terminal, which would corrupt an event stream on stdout.
+	interactive := !cmd.Bool("quiet") && cmd.String("events") == ""
+	started := time.Now()
+	err = executePostCreateCommand(statusWriter, cmdExec, execCommand, workTreePath, run.exportedEnv, interactive)
+	hookOpts.Events.Emit(events.Finished(events.Event{
+		Type:     events.ExecFinished,
+		Worktree: workTreePath,
+		Command:  execCommand,
+	}, started, err))
+	if err != nil {
 		return fmt.Errorf("worktree was created at '%s', but --exec command failed: %w", workTreePath, err)
 	}
 
-	if cmd.Bool("quiet") {
-		if _, err := fmt.Fprintln(stdoutWriter, workTreePath); err != nil {
-			return err
+	return nil
+}
+
+// resolveAddHookFilter turns --no-hooks, --only-hook and --skip-hook into a hook
+// filter. It returns nil when every hook should run.
+func resolveAddHookFilter(cmd *cli.Command, cfg *config.Config) (func(int, *config.Hook) bool, error) {
+	only, skip := cmd.StringSlice("only-hook"), cmd.StringSlice("skip-hook")
+	if cmd.Bool("no-hooks") {
+		if len(only) > 0 || len(skip) > 0 {
+			return nil, fmt.Errorf("--no-hooks cannot be combined with --only-hook or --skip-hook")
 		}
-		return nil
+		return func(int, *config.Hook) bool { return false }, nil
 	}
+	if len(only) == 0 && len(skip) == 0 {
+		return nil, nil
+	}
+	return buildHookFilter(cfg, only, skip, "")
+}
 
-	if err := displaySuccessMessage(stdoutWriter, branchName, workTreePath, cfg, mainRepoPath); err != nil {
-		return err
+// resolveAddEvents returns the emitter for --events or WTP_EVENTS_FD, or a nil
+// emitter when neither is set. The returned function releases the event stream.
+func resolveAddEvents(cmd *cli.Command, stdoutWriter io.Writer) (*events.Emitter, func(), error) {
+	noop := func() {}
+
+	if format := cmd.String("events"); format != "" {
+		if format != "json" {
+			return nil, noop, fmt.Errorf("invalid --events format '%s': only 'json' is supported", format)
+		}
+		if cmd.Bool("quiet") {
+			return nil, noop, fmt.Errorf("--quiet cannot be combined with --events; " +
+				"the worktree-created event carries the worktree path")
+		}
+		return events.NewEmitter(stdoutWriter), noop, nil
 	}
 
-	return nil
+	fdValue := strings.TrimSpace(os.Getenv("WTP_EVENTS_FD"))
+	if fdValue == "" {
+		return nil, noop, nil
+	}
+
+	fd, err := strconv.Atoi(fdValue)
+	if err != nil || fd < 0 {
+		return nil, noop, fmt.Errorf("invalid WTP_EVENTS_FD '%s': expected a file descriptor number", fdValue)
+	}
+	file := os.NewFile(uintptr(fd), "wtp-events")
+	if _, statErr := file.Stat(); statErr != nil {
+		return nil, noop, fmt.Errorf("invalid WTP_EVENTS_FD '%s': not an open file descriptor", fdValue)
+	}
+	return events.NewEmitter(file), func() { _ = file.Close() }, nil
 }
 
 func resolveAddWriters(cmd *cli.Command) (stdoutWriter, statusWriter io.Writer) {
@@ -172,7 +391,7 @@ func resolveAddWriters(cmd *cli.Command) (stdoutWriter, statusWriter io.Writer)
 	}
 
 	statusWriter = stdoutWriter
-	if cmd.Bool("quiet") {
+	if cmd.Bool("quiet") || cmd.String("events") != "" {
 		statusWriter = cmd.Root().ErrWriter
 		if statusWriter == nil {
 			statusWriter = os.Stderr
@@ -186,8 +405,16 @@ func resolveAddWriters(cmd *cli.Command) (stdoutWriter, statusWriter io.Writer)
 func buildWorktreeCommand(
 	cmd *cli.Command, workTreePath, _, resolvedTrack string,
 ) command.Command {
+	opts, commitish := resolveWorktreeAddArgs(cmd, resolvedTrack)
+	return command.GitWorktreeAdd(workTreePath, commitish, opts)
+}
+
+// resolveWorktreeAddArgs resolves the 'git worktree add' options and the commit-ish to check out.
+func resolveWorktreeAddArgs(cmd *cli.Command, resolvedTrack string) (command.GitWorktreeAddOptions, string) {
 	opts := command.GitWorktreeAddOptions{
 		Branch: cmd.String("branch"),
+		// A sparse worktree is checked out once its sparse-checkout patterns are set.
+		NoCheckout: cmd.String("sparse") != "",
 	}
 
 	// Use resolved track if provided
@@ -198,7 +425,10 @@ func buildWorktreeCommand(
 	var commitish string
 
 	// Handle different argument patterns based on flags
-	if resolvedTrack != "" {
+	if prBranch := pullRequestBranch(cmd); prBranch != "" {
+		// --pr checks out the branch the pull request was fetched into
+		commitish = prBranch
+	} else if resolvedTrack != "" {
 		// When using resolved tracking, the commitish is the remote branch
 		commitish = resolvedTrack
 		// If there's an argument, it's the local branch name (not used as commitish)
@@ -215,7 +445,35 @@ func buildWorktreeCommand(
 		}
 	}
 
-	return command.GitWorktreeAdd(workTreePath, commitish, opts)
+	return opts, commitish
+}
+
+// addHookTarget describes the worktree created by 'wtp add' to command hooks.
+// Head is left empty until the worktree exists. A pull request branch counts as
+// new until fetchPullRequest finds it already existed.
+func addHookTarget(
+	cmd *cli.Command, cfg *config.Config, mainRepoPath, workTreePath, branchName, resolvedTrack string,
+	pr *addPullRequest,
+) hooks.Target {
+	opts, commitish := resolveWorktreeAddArgs(cmd, resolvedTrack)
+	if commitish == "" {
+		commitish = "HEAD"
+	}
+
+	target := hooks.Target{
+		Name:        getWorktreeNameFromPath(workTreePath, cfg, mainRepoPath, false),
+		Branch:      branchName,
+		BaseRef:     commitish,
+		IsNewBranch: opts.Branch != "" || resolvedTrack != "",
+	}
+	if remote, _, ok := strings.Cut(resolvedTrack, "/"); ok {
+		target.Remote = remote
+	}
+	if pr != nil {
+		target.IsNewBranch = true
+		target.Remote = pr.Remote
+	}
+	return target
 }
 
 // analyzeGitWorktreeError analyzes git worktree errors and provides specific error messages
@@ -395,28 +653,94 @@ Original error: %v`, e.BranchName, e.BranchName, e.BranchName, e.BranchName, e.B
 }
 
 func executePostCreateHooks(w io.Writer, cfg *config.Config, repoPath, workTreePath string) error {
-	if cfg.HasHooks() {
-		if _, err := fmt.Fprintln(w, "\nExecuting post-create hooks..."); err != nil {
-			return err
-		}
+	_, err := executePostCreateHooksWithOptions(w, cfg, repoPath, workTreePath, hooks.Options{})
+	return err
+}
 
-		executor := hooks.NewExecutor(cfg, repoPath)
-		if err := executor.ExecutePostCreateHooks(w, workTreePath); err != nil {
-			return err
-		}
+// hookRun is what running the post-create hooks of a worktree leaves for the rest of a command.
+type hookRun struct {
+	// exportedEnv holds the variables hooks exported through GIT_WTP_ENV.
+	exportedEnv []string
+	// report holds the hook timings; it is nil when no hooks are configured.
+	report *hooks.TimingReport
+}
 
-		if _, err := fmt.Fprintln(w, "✓ All hooks executed successfully"); err != nil {
-			return err
-		}
+// executePostCreateHooksWithOptions runs the post-create hooks and prints their
+// timings. The returned hookRun is filled in even when a hook failed.
+func executePostCreateHooksWithOptions(
+	w io.Writer, cfg *config.Config, repoPath, workTreePath string, opts hooks.Options,
+) (hookRun, error) {
+	if !cfg.HasHooks() {
+		return hookRun{}, nil
+	}
+	if !selectsAnyHook(cfg, opts.Filter) {
+		_, err := fmt.Fprintln(w, "\nSkipping post-create hooks")
+		return hookRun{}, err
+	}
+
+	if _, err := fmt.Fprintln(w, "\nExecuting post-create hooks..."); err != nil {
+		return hookRun{}, err
+	}
+
+	if logFile := openHookLog(w, workTreePath); logFile != nil {
+		defer func() { _ = logFile.Close() }()
+		opts.Log = logFile
+	}
+
+	executor := hooks.NewExecutorWithOptions(cfg, repoPath, opts)
+	hookErr := executor.ExecutePostCreateHooks(w, workTreePath)
+
+	report := hooks.NewTimingReport(workTreePath, executor.Timings())
+	run := hookRun{exportedEnv: executor.ExportedEnv(), report: &report}
+	if err := hooks.WriteTimingSummary(w, report.Hooks); err != nil {
+		return run, err
+	}
+	if hookErr != nil {
+		return run, hookErr
+	}
+
+	_, err := fmt.Fprintln(w, "✓ All hooks executed successfully")
+	return run, err
+}
+
+// writeTimingReports writes hook timing reports as a JSON array to path for --timings.
+func writeTimingReports(path string, reports []hooks.TimingReport) error {
+	if reports == nil {
+		reports = []hooks.TimingReport{}
+	}
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), timingsFileMode); err != nil {
+		return fmt.Errorf("failed to write hook timings to %s: %w", path, err)
 	}
 	return nil
 }
 
+// openHookLog opens the per-worktree hook log read by 'wtp logs'. Logging is
+// best effort: it returns nil when the worktree has no git directory yet or the
+// log cannot be opened.
+func openHookLog(w io.Writer, workTreePath string) *os.File {
+	gitDir, err := git.WorktreeGitDir(workTreePath)
+	if err != nil {
+		return nil
+	}
+
+	logFile, err := hooks.OpenLog(hooks.LogPath(gitDir))
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "Warning: hook output will not be logged: %v\n", err)
+		return nil
+	}
+	return logFile
+}
+
 func executePostCreateCommand(
 	w io.Writer,
 	cmdExec command.Executor,
 	execCommand string,
 	workTreePath string,
+	env []string,
 	interactive bool,
 ) error {
 	if strings.TrimSpace(execCommand) == "" {
@@ -427,17 +751,8 @@ func executePostCreateCommand(
 		return err
 	}
 
-	commandToRun := command.Command{
-		WorkDir:     workTreePath,
-		Interactive: interactive,
-	}
-	if runtime.GOOS == "windows" {
-		commandToRun.Name = "cmd"
-		commandToRun.Args = []string{"/c", execCommand}
-	} else {
-		commandToRun.Name = "sh"
-		commandToRun.Args = []string{"-c", execCommand}
-	}
+	commandToRun := buildPostCreateCommand(execCommand, workTreePath, interactive)
+	commandToRun.Env = env
 
 	result, err := cmdExec.Execute([]command.Command{commandToRun})
 	if err != nil {
@@ -465,12 +780,85 @@ func executePostCreateCommand(
 	return nil
 }
 
+// buildPostCreateCommand builds the shell command used to run --exec in the new worktree.
+func buildPostCreateCommand(execCommand, workTreePath string, interactive bool) command.Command {
+	commandToRun := command.Command{
+		WorkDir:     workTreePath,
+		Interactive: interactive,
+	}
+	if runtime.GOOS == "windows" {
+		commandToRun.Name = "cmd"
+		commandToRun.Args = []string{"/c", execCommand}
+	} else {
+		commandToRun.Name = "sh"
+		commandToRun.Args = []string{"-c", execCommand}
+	}
+	return commandToRun
+}
+
+// displayAddPlan describes what 'wtp add' would do without creating the worktree.
+func displayAddPlan(
+	w io.Writer,
+	cfg *config.Config,
+	mainRepoPath, workTreePath string,
+	hookOpts hooks.Options,
+	worktreeCmd command.Command,
+	pr *addPullRequest,
+	sparseDirs []string,
+	submodules, execCommand string,
+) error {
+	if _, err := fmt.Fprintf(w, "Dry run: no changes will be made\n\n"+
+		"Worktree:\n  Path:    %s\n  Branch:  %s\n  Command: %s\n",
+		workTreePath, hookOpts.Target.Branch, worktreeCmd.String()); err != nil {
+		return err
+	}
+	if pr != nil {
+		if _, err := fmt.Fprintf(w, "  Fetch:   %s\n", pr.fetchCommand().String()); err != nil {
+			return err
+		}
+	}
+	if len(sparseDirs) > 0 {
+		if _, err := fmt.Fprintf(w, "  Sparse:  %s\n", strings.Join(sparseDirs, ", ")); err != nil {
+			return err
+		}
+	}
+	if submodules != config.SubmodulesNone {
+		if _, err := fmt.Fprintf(w, "  Submodules: %s\n", submodules); err != nil {
+			return err
+		}
+	}
+
+	var planErr error
+	if cfg.HasHooks() {
+		if _, err := fmt.Fprintln(w, "\nPost-create hooks:"); err != nil {
+			return err
+		}
+		hookExecutor := hooks.NewExecutorWithOptions(cfg, mainRepoPath, hooks.Options{
+			Filter: hookOpts.Filter,
+			Target: hookOpts.Target,
+		})
+		planErr = hookExecutor.PlanPostCreateHooks(w, workTreePath)
+	}
+
+	if strings.TrimSpace(execCommand) != "" {
+		execCmd := buildPostCreateCommand(execCommand, workTreePath, false)
+		if _, err := fmt.Fprintf(w, "\n--exec command:\n  %s (in %s)\n", execCmd.String(), workTreePath); err != nil {
+			return err
+		}
+	}
+
+	return planErr
+}
+
 func validateAddInput(cmd *cli.Command) error {
-	if cmd.Args().Len() == 0 && cmd.String("branch") == "" {
-		return errors.BranchNameRequired("wtp add <existing-branch> | -b <new-branch> [<commit>]")
+	if cmd.Args().Len() == 0 && cmd.String("branch") == "" && cmd.String("pr") == "" {
+		return errors.BranchNameRequired("wtp add <existing-branch> | -b <new-branch> [<commit>] | --pr <number>")
+	}
+	if err := validateAddPullRequest(cmd); err != nil {
+		return err
 	}
 
-	return nil
+	return config.ValidateSubmodules(cmd.String("submodules"))
 }
 
 func setupRepoAndConfig() (*git.Repository, *config.Config, string, error) {
@@ -661,8 +1049,9 @@ func resolveWorktreePath(
 func resolveBranchTracking(
 	cmd *cli.Command, branchName string, mainRepoPath string,
 ) (string, error) {
-	// Only auto-resolve branch when not creating a new branch and branch name exists
-	if cmd.String("branch") != "" || branchName == "" {
+	// Only auto-resolve branch when not creating a new branch and branch name exists;
+	// --pr fetches its branch itself
+	if cmd.String("branch") != "" || cmd.String("pr") != "" || branchName == "" {
 		return "", nil
 	}
 
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/hooks"
+)
+
+// prBranchPrefix prefixes the local branch 'wtp add --pr' fetches a pull request into.
+const prBranchPrefix = "pr/"
+
+// addPullRequest is the pull request selected with 'wtp add --pr'.
+type addPullRequest struct {
+	Number string
+	Remote string
+	// Ref is the pull request head on Remote, e.g. refs/pull/42/head.
+	Ref string
+	// Branch is the local branch the pull request is fetched into, e.g. pr/42.
+	Branch string
+}
+
+// fetchCommand creates Branch from the pull request, or fast-forwards it when it
+// exists, so that local commits on it are never overwritten. git rejects the
+// fetch when the branch cannot be fast-forwarded, e.g. after a force-push.
+func (pr *addPullRequest) fetchCommand() command.Command {
+	return command.GitFetch(pr.Remote, pr.Ref+":refs/heads/"+pr.Branch)
+}
+
+// resolveAddPullRequest returns the pull request of --pr, fetched from --remote,
+// else defaults.pr_remote, else origin. It returns nil without --pr.
+func resolveAddPullRequest(cmd *cli.Command, cfg *config.Config) *addPullRequest {
+	number := cmd.String("pr")
+	if number == "" {
+		return nil
+	}
+
+	remote := cmd.String("remote")
+	if remote == "" {
+		remote = cfg.Defaults.PRRemote
+	}
+	if remote == "" {
+		remote = config.DefaultPRRemote
+	}
+
+	return &addPullRequest{
+		Number: number,
+		Remote: remote,
+		Ref:    cfg.PullRequestRef(number),
+		Branch: pullRequestBranch(cmd),
+	}
+}
+
+// pullRequestBranch returns the local branch of --pr, or "" without it.
+func pullRequestBranch(cmd *cli.Command) string {
+	if number := cmd.String("pr"); number != "" {
+		return prBranchPrefix + number
+	}
+	return ""
+}
+
+// validateAddPullRequest checks --pr and the arguments it cannot be combined with.
+func validateAddPullRequest(cmd *cli.Command) error {
+	number := cmd.String("pr")
+	if number == "" {
+		if cmd.String("remote") != "" {
+			return fmt.Errorf("--remote can only be used with --pr")
+		}
+		return nil
+	}
+
+	if n, err := strconv.Atoi(number); err != nil || n <= 0 || strconv.Itoa(n) != number {
+		return fmt.Errorf("--pr must be a pull request number, got '%s'", number)
+	}
+	if cmd.Args().Len() > 0 || cmd.String("branch") != "" {
+		return fmt.Errorf("--pr cannot be combined with a branch argument or --branch; "+
+			"the worktree uses the branch %s%s", prBranchPrefix, number)
+	}
+	return nil
+}
+
+// fetchPullRequest fetches the pull request into its local branch before the worktree is added.
+// It tells hooks whether the fetch created the branch or updated one an earlier 'wtp add --pr' left.
+func fetchPullRequest(w io.Writer, executor command.Executor, pr *addPullRequest, target *hooks.Target) error {
+	if pr == nil {
+		return nil
+	}
+
+	existing, err := runGitCommandOutput(executor, command.GitRevParseVerify("refs/heads/"+pr.Branch))
+	target.IsNewBranch = err != nil || existing == ""
+
+	if _, err := runGitCommandOutput(executor, pr.fetchCommand()); err != nil {
+		if strings.Contains(err.Error(), "(non-fast-forward)") {
+			return errors.PullRequestBranchDiverged(pr.Number, pr.Branch, pr.Remote, pr.Ref)
+		}
+		return fmt.Errorf("failed to fetch pull request #%s from %s: %w", pr.Number, pr.Remote, err)
+	}
+
+	_, err = fmt.Fprintf(w, "Fetched pull request #%s from %s into %s\n", pr.Number, pr.Remote, pr.Branch)
+	return err
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/hooks"
+)
+
+// runAddWithPR runs 'wtp add' with the flags --pr needs against a mock executor.
+func runAddWithPR(
+	t *testing.T, cfg *config.Config, mock *mockExecCommandExecutor, args ...string,
+) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	app := &cli.Command{
+		Name: "add",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "branch", Aliases: []string{"b"}},
+			&cli.StringFlag{Name: "pr"},
+			&cli.StringFlag{Name: "remote"},
+			&cli.BoolFlag{Name: "dry-run"},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if err := validateAddInput(cmd); err != nil {
+				return err
+			}
+			return addCommandWithCommandExecutor(cmd, &buf, &buf, mock, cfg, "/test/repo")
+		},
+	}
+	err := app.Run(context.Background(), append([]string{"add"}, args...))
+	return buf.String(), err
+}
+
+func TestAddCommand_PullRequest(t *testing.T) {
+	t.Run("fetches the pull request into pr/<n> and checks it out", func(t *testing.T) {
+		cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+		mock := &mockExecCommandExecutor{}
+
+		output, err := runAddWithPR(t, cfg, mock, "--pr", "42")
+		require.NoError(t, err)
+
+		require.GreaterOrEqual(t, len(mock.executed), 3)
+		assert.Equal(t, command.GitRevParseVerify("refs/heads/pr/42"), mock.executed[0][0])
+		assert.Equal(t, command.GitFetch("origin", "refs/pull/42/head:refs/heads/pr/42"), mock.executed[1][0])
+		assert.Equal(t, command.GitWorktreeAdd("/test/worktrees/pr/42", "pr/42", command.GitWorktreeAddOptions{}),
+			mock.executed[2][0])
+		assert.Contains(t, output, "Fetched pull request #42 from origin into pr/42\n")
+	})
+
+	t.Run("uses the configured remote and refspec", func(t *testing.T) {
+		cfg := &config.Config{Defaults: config.Defaults{
+			BaseDir:   "../worktrees",
+			PRRemote:  "gitlab",
+			PRRefspec: "refs/merge-requests/{n}/head",
+		}}
+		mock := &mockExecCommandExecutor{}
+
+		_, err := runAddWithPR(t, cfg, mock, "--pr", "7")
+		require.NoError(t, err)
+		require.Greater(t, len(mock.executed), 1)
+		assert.Equal(t, command.GitFetch("gitlab", "refs/merge-requests/7/head:refs/heads/pr/7"), mock.executed[1][0])
+	})
+
+	t.Run("prefers --remote over the configured remote", func(t *testing.T) {
+		cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees", PRRemote: "gitlab"}}
+		mock := &mockExecCommandExecutor{}
+
+		_, err := runAddWithPR(t, cfg, mock, "--pr", "42", "--remote", "upstream")
+		require.NoError(t, err)
+		require.Greater(t, len(mock.executed), 1)
+		assert.Equal(t, command.GitFetch("upstream", "refs/pull/42/head:refs/heads/pr/42"), mock.executed[1][0])
+	})
+
+	t.Run("shows the fetch in the dry-run plan", func(t *testing.T) {
+		cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+		mock := &mockExecCommandExecutor{}
+
+		output, err := runAddWithPR(t, cfg, mock, "--pr", "42", "--dry-run")
+		require.NoError(t, err)
+		assert.Empty(t, mock.executed)
+		assert.Contains(t, output, "  Branch:  pr/42\n")
+		assert.Contains(t, output, "  Fetch:   git fetch origin refs/pull/42/head:refs/heads/pr/42\n")
+	})
+
+	t.Run("does not add the worktree when the fetch fails", func(t *testing.T) {
+		cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+		mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{
+			{Results: []command.Result{{}}},
+			{Results: []command.Result{{
+				Output: "fatal: couldn't find remote ref refs/pull/404/head",
+				Error:  assert.AnError,
+			}}},
+		}}
+
+		_, err := runAddWithPR(t, cfg, mock, "--pr", "404")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to fetch pull request #404 from origin")
+		assert.Contains(t, err.Error(), "couldn't find remote ref refs/pull/404/head")
+		assert.Len(t, mock.executed, 2)
+	})
+
+	t.Run("explains a fetch rejected after a force-push", func(t *testing.T) {
+		cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+		mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{
+			{Results: []command.Result{{Output: "abc123\n"}}},
+			{Results: []command.Result{{
+				Output: " ! [rejected]        refs/pull/42/head -> pr/42  (non-fast-forward)",
+				Error:  assert.AnError,
+			}}},
+		}}
+
+		_, err := runAddWithPR(t, cfg, mock, "--pr", "42")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pull request #42 can no longer fast-forward the local branch 'pr/42'")
+		assert.Contains(t, err.Error(), "git branch -D pr/42")
+		assert.Len(t, mock.executed, 2)
+	})
+}
+
+func TestFetchPullRequest_HookTarget(t *testing.T) {
+	pr := &addPullRequest{Number: "42", Remote: "upstream", Ref: "refs/pull/42/head", Branch: "pr/42"}
+
+	tests := []struct {
+		name        string
+		revParse    command.Result
+		isNewBranch bool
+	}{
+		{name: "fetch creates the branch", revParse: command.Result{Error: assert.AnError}, isNewBranch: true},
+		{name: "fetch updates an existing branch", revParse: command.Result{Output: "abc123\n"}, isNewBranch: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{
+				{Results: []command.Result{tt.revParse}},
+			}}
+			target := hooks.Target{IsNewBranch: true, Remote: "upstream"}
+
+			require.NoError(t, fetchPullRequest(&bytes.Buffer{}, mock, pr, &target))
+			assert.Equal(t, tt.isNewBranch, target.IsNewBranch)
+			assert.Equal(t, "upstream", target.Remote)
+			assert.Len(t, mock.executed, 2)
+		})
+	}
+}
+
+func TestValidateAddPullRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{name: "pull request number", args: []string{"--pr", "42"}},
+		{name: "not a number", args: []string{"--pr", "#42"}, expectedError: "--pr must be a pull request number"},
+		{name: "zero", args: []string{"--pr", "0"}, expectedError: "--pr must be a pull request number"},
+		{name: "leading zeros", args: []string{"--pr", "042"}, expectedError: "--pr must be a pull request number"},
+		{
+			name:          "with a branch argument",
+			args:          []string{"--pr", "42", "feature/auth"},
+			expectedError: "--pr cannot be combined with a branch argument or --branch",
+		},
+		{
+			name:          "with --branch",
+			args:          []string{"--pr", "42", "-b", "review"},
+			expectedError: "the worktree uses the branch pr/42",
+		},
+		{
+			name:          "--remote without --pr",
+			args:          []string{"feature/auth", "--remote", "upstream"},
+			expectedError: "--remote can only be used with --pr",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr error
+			app := &cli.Command{
+				Name: "add",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "branch", Aliases: []string{"b"}},
+					&cli.StringFlag{Name: "pr"},
+					&cli.StringFlag{Name: "remote"},
+				},
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					validationErr = validateAddPullRequest(cmd)
+					return nil
+				},
+			}
+
+			require.NoError(t, app.Run(context.Background(), append([]string{"add"}, tt.args...)))
+			if tt.expectedError == "" {
+				assert.NoError(t, validationErr)
+				return
+			}
+			require.Error(t, validationErr)
+			assert.Contains(t, validationErr.Error(), tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+// Variables to allow mocking in tests
+var addWriteSparseProfile = git.WriteSparseProfile
+
+// resolveAddSparse returns the directories of the sparse_profiles entry selected
+// with --sparse, or nil when the worktree is checked out in full.
+func resolveAddSparse(cmd *cli.Command, cfg *config.Config) ([]string, error) {
+	profile := cmd.String("sparse")
+	if profile == "" {
+		return nil, nil
+	}
+	return cfg.SparseProfile(profile)
+}
+
+// checkoutSparse limits a worktree created with --no-checkout to directories in
+// cone mode, checks it out and records profile for 'wtp list'.
+func checkoutSparse(w io.Writer, executor command.Executor, workTreePath, profile string, directories []string) error {
+	if profile == "" {
+		return nil
+	}
+
+	for _, cmd := range []command.Command{command.GitSparseCheckoutSet(directories), command.GitCheckout()} {
+		cmd.WorkDir = workTreePath
+		if _, err := runGitCommandOutput(executor, cmd); err != nil {
+			return fmt.Errorf("worktree was created at '%s', but sparse checkout failed: %w", workTreePath, err)
+		}
+	}
+	if err := addWriteSparseProfile(workTreePath, profile); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "Checked out sparse profile '%s': %s\n", profile, strings.Join(directories, ", "))
+	return err
+}
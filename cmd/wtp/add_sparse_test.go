@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func stubWriteSparseProfile(t *testing.T) map[string]string {
+	t.Helper()
+	original := addWriteSparseProfile
+	t.Cleanup(func() { addWriteSparseProfile = original })
+
+	recorded := map[string]string{}
+	addWriteSparseProfile = func(path, profile string) error {
+		recorded[path] = profile
+		return nil
+	}
+	return recorded
+}
+
+func TestCheckoutSparse(t *testing.T) {
+	const worktree = "/worktrees/web"
+
+	t.Run("sets the profile and checks out", func(t *testing.T) {
+		recorded := stubWriteSparseProfile(t)
+		mock := &mockExecCommandExecutor{}
+
+		var buf bytes.Buffer
+		err := checkoutSparse(&buf, mock, worktree, "web", []string{"apps/web", "libs/ui"})
+		require.NoError(t, err)
+
+		require.Len(t, mock.executed, 2)
+		assert.Equal(t, commandInDir(command.GitSparseCheckoutSet([]string{"apps/web", "libs/ui"}), worktree),
+			mock.executed[0][0])
+		assert.Equal(t, commandInDir(command.GitCheckout(), worktree), mock.executed[1][0])
+		assert.Equal(t, map[string]string{worktree: "web"}, recorded)
+		assert.Equal(t, "Checked out sparse profile 'web': apps/web, libs/ui\n", buf.String())
+	})
+
+	t.Run("does nothing without a profile", func(t *testing.T) {
+		recorded := stubWriteSparseProfile(t)
+		mock := &mockExecCommandExecutor{}
+
+		require.NoError(t, checkoutSparse(&bytes.Buffer{}, mock, worktree, "", nil))
+		assert.Empty(t, mock.executed)
+		assert.Empty(t, recorded)
+	})
+
+	t.Run("reports git errors", func(t *testing.T) {
+		recorded := stubWriteSparseProfile(t)
+		mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{{Results: []command.Result{{
+			Output: "fatal: specify directories rather than patterns",
+			Error:  assert.AnError,
+		}}}}}
+
+		err := checkoutSparse(&bytes.Buffer{}, mock, worktree, "web", []string{"apps/*"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "worktree was created at '/worktrees/web', but sparse checkout failed")
+		assert.Contains(t, err.Error(), "specify directories rather than patterns")
+		assert.Len(t, mock.executed, 1)
+		assert.Empty(t, recorded)
+	})
+}
+
+func TestAddCommand_SparseProfile(t *testing.T) {
+	cfg := &config.Config{
+		Defaults:       config.Defaults{BaseDir: "../worktrees"},
+		SparseProfiles: map[string][]string{"web": {"apps/web"}},
+	}
+
+	run := func(t *testing.T, args ...string) (*mockExecCommandExecutor, string, error) {
+		t.Helper()
+		mock := &mockExecCommandExecutor{}
+		var buf bytes.Buffer
+		app := &cli.Command{
+			Name: "add",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "branch", Aliases: []string{"b"}},
+				&cli.StringFlag{Name: "sparse"},
+				&cli.BoolFlag{Name: "dry-run"},
+			},
+			Action: func(_ context.Context, cmd *cli.Command) error {
+				return addCommandWithCommandExecutor(cmd, &buf, &buf, mock, cfg, "/test/repo")
+			},
+		}
+		err := app.Run(context.Background(), append([]string{"add"}, args...))
+		return mock, buf.String(), err
+	}
+
+	t.Run("creates the worktree without checkout", func(t *testing.T) {
+		recorded := stubWriteSparseProfile(t)
+
+		mock, output, err := run(t, "-b", "feature/web", "--sparse", "web")
+		require.NoError(t, err)
+
+		require.GreaterOrEqual(t, len(mock.executed), 3)
+		assert.Contains(t, mock.executed[0][0].Args, "--no-checkout")
+		assert.Equal(t, []string{"sparse-checkout", "set", "--cone", "--", "apps/web"}, mock.executed[1][0].Args)
+		assert.Equal(t, []string{"checkout"}, mock.executed[2][0].Args)
+		assert.Equal(t, map[string]string{"/test/worktrees/feature/web": "web"}, recorded)
+		assert.Contains(t, output, "Checked out sparse profile 'web': apps/web")
+	})
+
+	t.Run("shows the profile in the dry-run plan", func(t *testing.T) {
+		mock, output, err := run(t, "-b", "feature/web", "--sparse", "web", "--dry-run")
+		require.NoError(t, err)
+		assert.Empty(t, mock.executed)
+		assert.Contains(t, output, "--no-checkout")
+		assert.Contains(t, output, "  Sparse:  apps/web\n")
+	})
+
+	t.Run("rejects unknown profiles before creating the worktree", func(t *testing.T) {
+		mock, _, err := run(t, "-b", "feature/web", "--sparse", "mobile")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sparse profile 'mobile' is not defined; available profiles: web")
+		assert.Empty(t, mock.executed)
+	})
+}
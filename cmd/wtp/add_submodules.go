@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+// Variables to allow mocking in tests
+var (
+	addSubmodules         = git.Submodules
+	addSubmoduleReference = git.SubmoduleReference
+)
+
+// resolveAddSubmodules returns the submodule mode of 'wtp add': --submodules,
+// else defaults.submodules from .wtp.yml, else none.
+func resolveAddSubmodules(cmd *cli.Command, cfg *config.Config) string {
+	mode := cmd.String("submodules")
+	if mode == "" {
+		mode = cfg.Defaults.Submodules
+	}
+	if mode == "" {
+		return config.SubmodulesNone
+	}
+	return mode
+}
+
+// initSubmodules initializes the submodules of a new worktree according to mode.
+// Submodules the main worktree has initialized lend their objects through
+// --reference, so they are not cloned again.
+func initSubmodules(w io.Writer, executor command.Executor, mode, workTreePath string) error {
+	if mode == config.SubmodulesNone {
+		return nil
+	}
+
+	submodules, err := addSubmodules(workTreePath)
+	if err != nil {
+		return err
+	}
+
+	for _, submodule := range submodules {
+		reference := addSubmoduleReference(workTreePath, submodule.Name)
+		update := command.GitSubmoduleUpdate(submodule.Path, mode == config.SubmodulesRecursive, reference)
+		update.WorkDir = workTreePath
+		if _, err := runGitCommandOutput(executor, update); err != nil {
+			return err
+		}
+
+		message := "Initialized submodule " + submodule.Path
+		if reference != "" {
+			message += " (sharing objects with the main worktree)"
+		}
+		if _, err := fmt.Fprintln(w, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
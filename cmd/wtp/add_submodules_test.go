@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+func stubSubmodules(t *testing.T, submodules []git.Submodule, references map[string]string) {
+	t.Helper()
+	originalSubmodules, originalReference := addSubmodules, addSubmoduleReference
+	t.Cleanup(func() {
+		addSubmodules, addSubmoduleReference = originalSubmodules, originalReference
+	})
+	addSubmodules = func(string) ([]git.Submodule, error) { return submodules, nil }
+	addSubmoduleReference = func(_, name string) string { return references[name] }
+}
+
+func TestInitSubmodules(t *testing.T) {
+	submodules := []git.Submodule{
+		{Name: "lib", Path: "libs/lib"},
+		{Name: "docs/theme", Path: "docs/theme"},
+	}
+	references := map[string]string{"lib": "/src/repo/.git/modules/lib"}
+	const worktree = "/worktrees/feature"
+
+	t.Run("borrows objects from the main worktree where it can", func(t *testing.T) {
+		stubSubmodules(t, submodules, references)
+		mock := &mockExecCommandExecutor{}
+
+		var buf bytes.Buffer
+		err := initSubmodules(&buf, mock, config.SubmodulesInit, worktree)
+		require.NoError(t, err)
+
+		require.Len(t, mock.executed, 2)
+		assert.Equal(t, commandInDir(command.GitSubmoduleUpdate("libs/lib", false, references["lib"]), worktree),
+			mock.executed[0][0])
+		assert.Equal(t, commandInDir(command.GitSubmoduleUpdate("docs/theme", false, ""), worktree), mock.executed[1][0])
+		assert.Equal(t, "Initialized submodule libs/lib (sharing objects with the main worktree)\n"+
+			"Initialized submodule docs/theme\n", buf.String())
+	})
+
+	t.Run("recurses into nested submodules", func(t *testing.T) {
+		stubSubmodules(t, submodules[:1], nil)
+		mock := &mockExecCommandExecutor{}
+
+		err := initSubmodules(&bytes.Buffer{}, mock, config.SubmodulesRecursive, worktree)
+		require.NoError(t, err)
+		require.Len(t, mock.executed, 1)
+		assert.Equal(t, commandInDir(command.GitSubmoduleUpdate("libs/lib", true, ""), worktree), mock.executed[0][0])
+	})
+
+	t.Run("does nothing when disabled", func(t *testing.T) {
+		stubSubmodules(t, submodules, references)
+		mock := &mockExecCommandExecutor{}
+
+		var buf bytes.Buffer
+		require.NoError(t, initSubmodules(&buf, mock, config.SubmodulesNone, worktree))
+		assert.Empty(t, mock.executed)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("reports git errors", func(t *testing.T) {
+		stubSubmodules(t, submodules, references)
+		mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{{Results: []command.Result{{
+			Output: "fatal: repository 'https://example.com/lib.git' not found",
+			Error:  assert.AnError,
+		}}}}}
+
+		err := initSubmodules(&bytes.Buffer{}, mock, config.SubmodulesInit, worktree)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "repository 'https://example.com/lib.git' not found")
+		assert.Len(t, mock.executed, 1)
+	})
+}
+
+func TestResolveAddSubmodules(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		defaults string
+		expected string
+	}{
+		{name: "none by default", expected: config.SubmodulesNone},
+		{name: "from the config", defaults: config.SubmodulesRecursive, expected: config.SubmodulesRecursive},
+		{
+			name:     "flag overrides the config",
+			args:     []string{"--submodules", "none"},
+			defaults: config.SubmodulesRecursive,
+			expected: config.SubmodulesNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resolved string
+			app := &cli.Command{
+				Name:  "add",
+				Flags: []cli.Flag{&cli.StringFlag{Name: "submodules"}},
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					cfg := &config.Config{Defaults: config.Defaults{Submodules: tt.defaults}}
+					resolved = resolveAddSubmodules(cmd, cfg)
+					return nil
+				},
+			}
+
+			require.NoError(t, app.Run(context.Background(), append([]string{"add"}, tt.args...)))
+			assert.Equal(t, tt.expected, resolved)
+		})
+	}
+}
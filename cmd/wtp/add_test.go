@@ -3,7 +3,11 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
@@ -14,6 +18,7 @@ import (
 	"github.com/satococoa/wtp/v2/internal/command"
 	"github.com/satococoa/wtp/v2/internal/config"
 	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/hooks"
 )
 
 // ===== Command Structure Tests =====
@@ -283,6 +288,93 @@ func TestValidateAddInput(t *testing.T) {
 	}
 }
 
+func TestAddHookTarget(t *testing.T) {
+	cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+	mainRepoPath := "/test/repo"
+
+	tests := []struct {
+		name          string
+		flags         map[string]any
+		args          []string
+		resolvedTrack string
+		expected      hooks.Target
+	}{
+		{
+			name: "existing local branch",
+			args: []string{"feature/auth"},
+			expected: hooks.Target{
+				Name:    "feature/auth",
+				Branch:  "feature/auth",
+				BaseRef: "feature/auth",
+			},
+		},
+		{
+			name:  "new branch from HEAD",
+			flags: map[string]any{"branch": "feature/new"},
+			expected: hooks.Target{
+				Name:        "feature/new",
+				Branch:      "feature/new",
+				BaseRef:     "HEAD",
+				IsNewBranch: true,
+			},
+		},
+		{
+			name:  "new branch from commit",
+			flags: map[string]any{"branch": "hotfix/urgent"},
+			args:  []string{"main"},
+			expected: hooks.Target{
+				Name:        "hotfix/urgent",
+				Branch:      "hotfix/urgent",
+				BaseRef:     "main",
+				IsNewBranch: true,
+			},
+		},
+		{
+			name:          "remote branch tracked automatically",
+			args:          []string{"feature/remote"},
+			resolvedTrack: "upstream/feature/remote",
+			expected: hooks.Target{
+				Name:        "feature/remote",
+				Branch:      "feature/remote",
+				BaseRef:     "upstream/feature/remote",
+				IsNewBranch: true,
+				Remote:      "upstream",
+			},
+		},
+		{
+			name:  "pull request fetched from its remote",
+			flags: map[string]any{"pr": "42"},
+			expected: hooks.Target{
+				Name:        "pr/42",
+				Branch:      "pr/42",
+				BaseRef:     "pr/42",
+				IsNewBranch: true,
+				Remote:      "origin",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := tt.flags
+			if flags == nil {
+				flags = map[string]any{}
+			}
+			cmd := createTestCLICommand(flags, tt.args)
+
+			firstArg := pullRequestBranch(cmd)
+			if len(tt.args) > 0 {
+				firstArg = tt.args[0]
+			}
+			workTreePath, branchName := resolveWorktreePath(cfg, mainRepoPath, firstArg, cmd)
+
+			target := addHookTarget(cmd, cfg, mainRepoPath, workTreePath, branchName, tt.resolvedTrack,
+				resolveAddPullRequest(cmd, cfg))
+			assert.Equal(t, tt.expected, target)
+		})
+	}
+}
+
 func TestResolveWorktreePath(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -447,6 +539,7 @@ func TestAddCommand_QuietModeOutput(t *testing.T) {
 		cmd := createTestCLICommand(map[string]any{
 			"branch": "feature/hook-fail",
 			"quiet":  true,
+			"trust":  true,
 		}, []string{})
 		mockExec := &mockCommandExecutor{}
 		cfg := &config.Config{
@@ -579,6 +672,224 @@ func TestAddCommand_ExecFailureKeepsCreationContext(t *testing.T) {
 	assert.Len(t, exec.executedCommands, 2)
 }
 
+func TestAddCommand_DryRun(t *testing.T) {
+	cmd := createTestCLICommand(map[string]any{
+		"branch":  "feature/auth",
+		"exec":    "npm test",
+		"dry-run": true,
+	}, []string{})
+	var buf bytes.Buffer
+	mockExec := &mockCommandExecutor{}
+	cfg := &config.Config{
+		Defaults: config.Defaults{BaseDir: "/test/worktrees"},
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeCommand, Command: "npm ci", Env: map[string]string{"NODE_ENV": "test"}},
+			},
+		},
+	}
+
+	err := addCommandWithCommandExecutor(cmd, &buf, &buf, mockExec, cfg, "/test/repo")
+
+	require.NoError(t, err)
+	assert.Empty(t, mockExec.executedCommands)
+	output := buf.String()
+	assert.Contains(t, output, "Dry run: no changes will be made")
+	assert.Contains(t, output, "git worktree add -b feature/auth /test/worktrees/feature/auth")
+	assert.Contains(t, output, "Hook 1 of 1 (command)")
+	assert.Contains(t, output, "NODE_ENV=test")
+	assert.Contains(t, output, "GIT_WTP_WORKTREE_PATH=/test/worktrees/feature/auth")
+	assert.Contains(t, output, "--exec command:")
+	assert.NotContains(t, output, "Worktree created successfully")
+}
+
+func TestAddCommand_EventsJSON(t *testing.T) {
+	cmd := createTestCLICommand(map[string]any{
+		"branch": "feature/auth",
+		"exec":   "npm test",
+		"events": "json",
+	}, []string{})
+	var stdout, status bytes.Buffer
+	mockExec := &mockCommandExecutor{}
+	cfg := &config.Config{Defaults: config.Defaults{BaseDir: "/test/worktrees"}}
+
+	err := addCommandWithCommandExecutor(cmd, &stdout, &status, mockExec, cfg, "/test/repo")
+	require.NoError(t, err)
+
+	var types []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		var event map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &event), "stdout must only contain events: %q", line)
+		types = append(types, event["type"].(string))
+		assert.Equal(t, "/test/worktrees/feature/auth", event["worktree"])
+		assert.InDelta(t, 0, event["exit_code"], 0)
+	}
+	assert.Equal(t, []string{"worktree_created", "exec_finished"}, types)
+	assert.Contains(t, status.String(), "Worktree created successfully")
+	require.NotEmpty(t, mockExec.executedCommands)
+	assert.False(t, mockExec.executedCommands[len(mockExec.executedCommands)-1].Interactive)
+}
+
+func TestAddCommand_Timings(t *testing.T) {
+	tempDir := t.TempDir()
+	mainRepoPath := filepath.Join(tempDir, "repo")
+	require.NoError(t, os.MkdirAll(mainRepoPath, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(mainRepoPath, ".env"), []byte("A=1"), 0o600))
+	timingsPath := filepath.Join(tempDir, "timings.json")
+
+	cmd := createTestCLICommand(map[string]any{
+		"branch":  "feature/auth",
+		"timings": timingsPath,
+	}, []string{})
+	cfg := &config.Config{
+		Defaults: config.Defaults{BaseDir: filepath.Join(tempDir, "worktrees")},
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeCopy, From: ".env", To: ".env"},
+				{Type: config.HookTypeCopy, From: "missing", To: "missing"},
+				{Type: config.HookTypeCopy, From: ".env", To: ".env.copy"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := addCommandWithCommandExecutor(cmd, &buf, &buf, &mockCommandExecutor{}, cfg, mainRepoPath)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Hook timings:")
+	assert.Regexp(t, `1\s+copy\s+ok\s+\S+\s+\.env → \.env`, output)
+	assert.Regexp(t, `2\s+copy\s+failed\s+\S+\s+missing → missing`, output)
+	assert.Regexp(t, `3\s+copy\s+not run\s+-\s+\.env → \.env\.copy`, output)
+
+	data, err := os.ReadFile(timingsPath)
+	require.NoError(t, err)
+	var reports []hooks.TimingReport
+	require.NoError(t, json.Unmarshal(data, &reports))
+	require.Len(t, reports, 1)
+	assert.Equal(t, filepath.Join(tempDir, "worktrees", "feature", "auth"), reports[0].Worktree)
+	require.Len(t, reports[0].Hooks, 3)
+	assert.Equal(t, []string{hooks.TimingOK, hooks.TimingFailed, hooks.TimingNotRun},
+		[]string{reports[0].Hooks[0].Status, reports[0].Hooks[1].Status, reports[0].Hooks[2].Status})
+}
+
+func TestAddCommand_HookSelection(t *testing.T) {
+	newConfig := func(baseDir string) *config.Config {
+		return &config.Config{
+			Defaults: config.Defaults{BaseDir: baseDir},
+			Hooks: config.Hooks{
+				PostCreate: []config.Hook{
+					{ID: "env", Type: config.HookTypeCopy, From: ".env", To: ".env"},
+					{ID: "deps", Name: "Install dependencies", Type: config.HookTypeCommand, Command: "npm ci"},
+					{Name: "local env", Type: config.HookTypeCopy, From: ".env", To: ".env.local"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		flags        map[string]any
+		expectFiles  []string
+		expectOutput string
+		expectError  string
+	}{
+		{
+			name:         "no-hooks runs nothing",
+			flags:        map[string]any{"no-hooks": true},
+			expectOutput: "Skipping post-create hooks",
+		},
+		{
+			name:        "skip-hook by id and name",
+			flags:       map[string]any{"skip-hook": []string{"deps", "local env"}},
+			expectFiles: []string{".env"},
+		},
+		{
+			name:        "only-hook by name",
+			flags:       map[string]any{"only-hook": []string{"local env"}},
+			expectFiles: []string{".env.local"},
+		},
+		{
+			name:        "unknown hook",
+			flags:       map[string]any{"skip-hook": []string{"lint"}},
+			expectError: "invalid hook selector 'lint'",
+		},
+		{
+			name:        "no-hooks with a selector",
+			flags:       map[string]any{"no-hooks": true, "only-hook": []string{"env"}},
+			expectError: "--no-hooks cannot be combined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			mainRepoPath := filepath.Join(tempDir, "repo")
+			require.NoError(t, os.MkdirAll(mainRepoPath, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(mainRepoPath, ".env"), []byte("A=1"), 0o600))
+			worktreePath := filepath.Join(tempDir, "worktrees", "feature", "auth")
+			require.NoError(t, os.MkdirAll(worktreePath, 0o755))
+
+			tt.flags["branch"] = "feature/auth"
+			cmd := createTestCLICommand(tt.flags, []string{})
+			mockExec := &mockCommandExecutor{}
+
+			var buf bytes.Buffer
+			cfg := newConfig(filepath.Join(tempDir, "worktrees"))
+			err := addCommandWithCommandExecutor(cmd, &buf, &buf, mockExec, cfg, mainRepoPath)
+
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				assert.Empty(t, mockExec.executedCommands)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotContains(t, buf.String(), "Running hook 2")
+			assert.Contains(t, buf.String(), tt.expectOutput)
+			for _, name := range []string{".env", ".env.local"} {
+				_, statErr := os.Stat(filepath.Join(worktreePath, name))
+				assert.Equal(t, slices.Contains(tt.expectFiles, name), statErr == nil, name)
+			}
+		})
+	}
+}
+
+func TestAddCommand_EventsErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		flags         map[string]any
+		expectedError string
+	}{
+		{
+			name:          "invalid format",
+			flags:         map[string]any{"events": "yaml"},
+			expectedError: "only 'json' is supported",
+		},
+		{
+			name:          "with --quiet",
+			flags:         map[string]any{"events": "json", "quiet": true},
+			expectedError: "--quiet cannot be combined with --events",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.flags["branch"] = "feature/auth"
+			cmd := createTestCLICommand(tt.flags, []string{})
+			var buf bytes.Buffer
+			mockExec := &mockCommandExecutor{}
+
+			err := addCommandWithCommandExecutor(cmd, &buf, &buf, mockExec, &config.Config{}, "/test/repo")
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			assert.Empty(t, mockExec.executedCommands)
+			assert.Empty(t, buf.String())
+		})
+	}
+}
+
 // ===== Edge Cases Tests =====
 
 func TestAddCommand_InternationalCharacters(t *testing.T) {
@@ -636,10 +947,19 @@ func createTestCLICommand(flags map[string]any, args []string) *cli.Command {
 					&cli.BoolFlag{Name: "detach"},
 					&cli.StringFlag{Name: "branch"},
 					&cli.StringFlag{Name: "track"},
+					&cli.StringFlag{Name: "pr"},
+					&cli.StringFlag{Name: "remote"},
 					&cli.StringFlag{Name: "exec"},
 					&cli.BoolFlag{Name: "quiet"},
 					&cli.BoolFlag{Name: "cd"},
 					&cli.BoolFlag{Name: "no-cd"},
+					&cli.BoolFlag{Name: "dry-run"},
+					&cli.StringFlag{Name: "events"},
+					&cli.BoolFlag{Name: "trust"},
+					&cli.StringFlag{Name: "timings"},
+					&cli.BoolFlag{Name: "no-hooks"},
+					&cli.StringSliceFlag{Name: "only-hook"},
+					&cli.StringSliceFlag{Name: "skip-hook"},
 				},
 				Action: func(_ context.Context, _ *cli.Command) error {
 					return nil
@@ -657,6 +977,10 @@ func createTestCLICommand(flags map[string]any, args []string) *cli.Command {
 			}
 		case string:
 			cmdArgs = append(cmdArgs, "--"+key, v)
+		case []string:
+			for _, item := range v {
+				cmdArgs = append(cmdArgs, "--"+key, item)
+			}
 		}
 	}
 	cmdArgs = append(cmdArgs, args...)
@@ -867,7 +1191,7 @@ func TestExecutePostCreateCommand(t *testing.T) {
 		var buf bytes.Buffer
 		mockExec := &mockCommandExecutor{}
 
-		err := executePostCreateCommand(&buf, mockExec, "", "/test/worktree", true)
+		err := executePostCreateCommand(&buf, mockExec, "", "/test/worktree", nil, true)
 		require.NoError(t, err)
 		assert.Empty(t, buf.String())
 		assert.Empty(t, mockExec.executedCommands)
@@ -877,7 +1201,7 @@ func TestExecutePostCreateCommand(t *testing.T) {
 		var buf bytes.Buffer
 		mockExec := &mockCommandExecutor{}
 
-		err := executePostCreateCommand(&buf, mockExec, "echo hello", "/test/worktree", true)
+		err := executePostCreateCommand(&buf, mockExec, "echo hello", "/test/worktree", nil, true)
 		require.NoError(t, err)
 		require.Len(t, mockExec.executedCommands, 1)
 		assert.Equal(t, "/test/worktree", mockExec.executedCommands[0].WorkDir)
@@ -896,11 +1220,22 @@ func TestExecutePostCreateCommand(t *testing.T) {
 		var buf bytes.Buffer
 		mockExec := &mockCommandExecutor{}
 
-		err := executePostCreateCommand(&buf, mockExec, "echo hello", "/test/worktree", false)
+		err := executePostCreateCommand(&buf, mockExec, "echo hello", "/test/worktree", nil, false)
 		require.NoError(t, err)
 		require.Len(t, mockExec.executedCommands, 1)
 		assert.False(t, mockExec.executedCommands[0].Interactive)
 	})
+
+	t.Run("should pass variables exported by hooks", func(t *testing.T) {
+		var buf bytes.Buffer
+		mockExec := &mockCommandExecutor{}
+
+		env := []string{"DB_NAME=app_feature"}
+		err := executePostCreateCommand(&buf, mockExec, "echo hello", "/test/worktree", env, false)
+		require.NoError(t, err)
+		require.Len(t, mockExec.executedCommands, 1)
+		assert.Equal(t, env, mockExec.executedCommands[0].Env)
+	})
 }
 
 func TestDisplaySuccessMessage_Integration(t *testing.T) {
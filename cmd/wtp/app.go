@@ -21,12 +21,23 @@ func newApp() *cli.Command {
 			NewAddCommand(),
 			NewListCommand(),
 			NewRemoveCommand(),
+			NewLockCommand(),
+			NewUnlockCommand(),
+			NewPruneCommand(),
+			NewCleanCommand(),
+			NewMoveCommand(),
+			NewCloneCommand(),
 			NewInitCommand(),
 			NewCdCommand(),
 			NewExecCommand(),
+			NewHooksCommand(),
+			NewLogsCommand(),
+			NewDoctorCommand(),
+			NewTrustCommand(),
 			// Built-in completion is automatically provided by urfave/cli
 			NewHookCommand(),
 			NewShellInitCommand(),
+			NewSandboxExecCommand(),
 		},
 	}
 }
@@ -87,6 +87,7 @@ func cdCommandWithCommandExecutor(
 
 	// Parse worktrees from command output
 	worktrees := parseWorktreesFromOutput(result.Results[0].Output)
+	markDefaultWorktree(executor, worktrees)
 
 	// Find the main worktree path
 	mainWorktreePath := findMainWorktreePath(worktrees)
@@ -199,7 +200,7 @@ func writeManagedWorktreesForCd(
 ) error {
 	for i := range worktrees {
 		wt := &worktrees[i]
-		if !wt.IsMain && isWorktreeManagedCommon(wt.Path, cfg, mainRepoPath, wt.IsMain) {
+		if !wt.IsMain && !wt.Bare && isWorktreeManagedCommon(wt.Path, cfg, mainRepoPath, wt.IsMain) {
 			name := getWorktreeNameFromPathCd(wt.Path, cfg, mainRepoPath, wt.IsMain)
 			if wt.Path == cwd {
 				if _, err := fmt.Fprintf(w, "%s*\n", name); err != nil {
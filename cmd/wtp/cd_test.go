@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
 )
 
 // ===== Critical User Scenarios =====
@@ -77,6 +79,59 @@ branch refs/heads/feature/auth
 	}
 }
 
+func TestCdCommand_BareRepositoryLayout(t *testing.T) {
+	worktreeList := `worktree /Users/dev/project/.bare
+bare
+
+worktree /Users/dev/project/main
+HEAD abc123
+branch refs/heads/main
+
+worktree /Users/dev/project/stable
+HEAD def456
+branch refs/heads/stable
+
+worktree /Users/dev/project/worktrees/feature/auth
+HEAD ghi789
+branch refs/heads/feature/auth
+`
+	newExecutor := func(defaultWorktree string) *mockExecCommandExecutor {
+		configResult := command.Result{Output: defaultWorktree}
+		if defaultWorktree == "" {
+			configResult.Error = assert.AnError
+		}
+		return &mockExecCommandExecutor{results: []*command.ExecutionResult{
+			{Results: []command.Result{{Output: worktreeList}}},
+			{Results: []command.Result{configResult}},
+		}}
+	}
+
+	t.Run("@ is the configured default worktree", func(t *testing.T) {
+		mock := newExecutor("../stable")
+		worktrees, err := listWorktreesWithExecutor(mock)
+		require.NoError(t, err)
+
+		assert.Equal(t, command.GitConfigGet("wtp.defaultWorktree"), mock.executed[1][0])
+		mainPath := findMainWorktreePath(worktrees)
+		assert.Equal(t, "/Users/dev/project/stable", mainPath)
+		assert.Equal(t, "/Users/dev/project/stable", resolveWorktreePathByName("@", worktrees, mainPath))
+	})
+
+	t.Run("@ falls back to the worktree on main", func(t *testing.T) {
+		worktrees, err := listWorktreesWithExecutor(newExecutor(""))
+		require.NoError(t, err)
+
+		mainPath := findMainWorktreePath(worktrees)
+		assert.Equal(t, "/Users/dev/project/main", mainPath)
+		assert.Equal(t, "/Users/dev/project/main", resolveWorktreePathByName("@", worktrees, mainPath))
+		assert.Equal(t, "/Users/dev/project/worktrees/feature/auth",
+			resolveWorktreePathByName("feature/auth", worktrees, mainPath))
+		assert.Empty(t, resolveWorktreePathByName(".bare", worktrees, mainPath),
+			"the bare repository is not a worktree to switch to")
+		assert.Equal(t, []string{"@", "feature/auth"}, availableManagedWorktreeNames(worktrees, mainPath))
+	})
+}
+
 // Test the architectural guarantee: no environment variable dependency
 func TestCdCommand_NoEnvironmentVariableDependency(t *testing.T) {
 	// This test ensures we maintain the "pure function" architecture
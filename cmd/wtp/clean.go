@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+// Variables to allow mocking in tests
+var (
+	cleanMergeState     = git.BranchMergeState
+	cleanDefaultBranch  = git.DefaultBranch
+	cleanWorktreeStatus = git.WorktreeStatus
+)
+
+// NewCleanCommand creates the clean command definition
+func NewCleanCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "clean",
+		Usage:     "Remove managed worktrees whose branches are merged",
+		UsageText: "wtp clean --merged [--into <branch>] [--with-branch] [--force] [--dry-run] [--yes]",
+		Description: "Finds managed worktrees whose branches are merged into the default branch (origin/HEAD, " +
+			"else main or master) or the branch given with --into, and removes them once confirmed. Branches " +
+			"that were rebased or squashed onto the target count as merged as well, branches without commits of " +
+			"their own never do. Worktrees with uncommitted " +
+			"changes are skipped unless --force is given; locked worktrees and the current one are always kept.\n\n" +
+			"Examples:\n" +
+			"  wtp clean --merged                      # Show merged worktrees and ask before removing them\n" +
+			"  wtp clean --merged --with-branch        # Also delete their branches\n" +
+			"  wtp clean --merged --into develop       # Compare with develop instead\n" +
+			"  wtp clean --merged --dry-run            # Only show what would be removed",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "merged",
+				Usage: "Remove worktrees whose branches are merged (required)",
+			},
+			&cli.StringFlag{
+				Name:  "into",
+				Usage: "Branch the worktree branches must be merged into (default: the default branch)",
+			},
+			&cli.BoolFlag{
+				Name:  "with-branch",
+				Usage: "Also delete the branches of removed worktrees",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "Also remove worktrees with uncommitted changes",
+			},
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"n"},
+				Usage:   "Show what would be removed without changing anything",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Remove without asking for confirmation",
+			},
+		},
+		Action: cleanCommand,
+	}
+}
+
+type cleanOptions struct {
+	Into       string
+	WithBranch bool
+	Force      bool
+	DryRun     bool
+	Yes        bool
+}
+
+type cleanTarget struct {
+	worktree git.Worktree
+	name     string
+	state    git.MergeState
+	dirty    bool
+}
+
+type cleanSkip struct {
+	name   string
+	reason string
+}
+
+// cleanPlan lists the merged worktrees 'wtp clean' would remove and those it keeps.
+type cleanPlan struct {
+	into    string
+	remove  []cleanTarget
+	skipped []cleanSkip
+}
+
+func cleanCommand(_ context.Context, cmd *cli.Command) error {
+	if !cmd.Bool("merged") {
+		return fmt.Errorf("choose which worktrees to clean: use --merged to remove worktrees whose branches are merged")
+	}
+
+	w := cmd.Root().Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return errors.DirectoryAccessFailed("access current", ".", err)
+	}
+
+	_, cfg, mainRepoPath, err := setupRepoAndConfig()
+	if err != nil {
+		return err
+	}
+
+	opts := cleanOptions{
+		Into:       cmd.String("into"),
+		WithBranch: cmd.Bool("with-branch"),
+		Force:      cmd.Bool("force"),
+		DryRun:     cmd.Bool("dry-run"),
+		Yes:        cmd.Bool("yes"),
+	}
+	return cleanCommandWithCommandExecutor(w, os.Stdin, command.NewRealExecutor(), cfg, mainRepoPath, cwd, opts)
+}
+
+func cleanCommandWithCommandExecutor(
+	w io.Writer, in io.Reader, executor command.Executor, cfg *config.Config, mainRepoPath, cwd string,
+	opts cleanOptions,
+) error {
+	into := opts.Into
+	if into == "" {
+		into = cleanDefaultBranch(mainRepoPath)
+		if into == "" {
+			return fmt.Errorf("could not determine the default branch; pass the target branch with --into")
+		}
+	}
+
+	worktrees, err := listWorktreesWithExecutor(executor)
+	if err != nil {
+		return err
+	}
+
+	plan, err := buildCleanPlan(worktrees, cfg, mainRepoPath, cwd, into, opts.Force)
+	if err != nil {
+		return err
+	}
+	if err := writeCleanPlan(w, plan); err != nil {
+		return err
+	}
+	if len(plan.remove) == 0 || opts.DryRun {
+		return nil
+	}
+
+	if !opts.Yes {
+		question := "Remove these worktrees?"
+		if opts.WithBranch {
+			question = "Remove these worktrees and their branches?"
+		}
+		confirmed, err := confirmAction(w, in, question,
+			"run 'wtp clean --merged --yes' to remove them or add --dry-run to only show them")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			_, err := fmt.Fprintln(w, "Nothing was removed")
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	for _, target := range plan.remove {
+		if err := removeCleanTarget(w, executor, mainRepoPath, target, opts.WithBranch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildCleanPlan finds the managed worktrees whose branches are merged into into.
+func buildCleanPlan(
+	worktrees []git.Worktree, cfg *config.Config, mainRepoPath, cwd, into string, force bool,
+) (cleanPlan, error) {
+	plan := cleanPlan{into: into}
+	for _, wt := range worktrees {
+		if wt.IsMain || wt.Bare || wt.Detached || wt.Branch == "" || wt.Prunable ||
+			!isWorktreeManagedCommon(wt.Path, cfg, mainRepoPath, wt.IsMain) {
+			continue
+		}
+
+		state, err := cleanMergeState(mainRepoPath, wt.Branch, into)
+		if err != nil {
+			return plan, err
+		}
+		if state == git.NotMerged {
+			continue
+		}
+
+		name := getWorktreeDisplayName(wt, cfg, mainRepoPath)
+		dirty, reason := cleanSkipReason(wt, cwd, force)
+		if reason != "" {
+			plan.skipped = append(plan.skipped, cleanSkip{name: name, reason: reason})
+			continue
+		}
+		plan.remove = append(plan.remove, cleanTarget{worktree: wt, name: name, state: state, dirty: dirty})
+	}
+	return plan, nil
+}
+
+// cleanSkipReason explains why a merged worktree is kept, or returns "" along
+// with whether removing it discards uncommitted changes.
+func cleanSkipReason(wt git.Worktree, cwd string, force bool) (dirty bool, reason string) {
+	if wt.Locked {
+		return false, "locked"
+	}
+	if isPathWithin(wt.Path, cwd) {
+		return false, "current worktree"
+	}
+
+	status, err := cleanWorktreeStatus(wt.Path, "")
+	if err != nil {
+		return false, "status unavailable"
+	}
+	dirty = status.Changed > 0 || status.Untracked > 0
+	if dirty && !force {
+		return dirty, "uncommitted changes (use --force to remove anyway)"
+	}
+	return dirty, ""
+}
+
+func writeCleanPlan(w io.Writer, plan cleanPlan) error {
+	var b strings.Builder
+	if len(plan.remove) == 0 {
+		fmt.Fprintf(&b, "No worktrees with branches merged into %s to remove\n", plan.into)
+	} else {
+		width := 0
+		for _, target := range plan.remove {
+			width = max(width, utf8.RuneCountInString(target.name))
+		}
+		fmt.Fprintf(&b, "Worktrees with branches merged into %s:\n", plan.into)
+		for _, target := range plan.remove {
+			state := target.state.String()
+			if target.dirty {
+				state += ", uncommitted changes will be lost"
+			}
+			fmt.Fprintf(&b, "  %-*s  %s\n", width, target.name, state)
+		}
+	}
+
+	if len(plan.skipped) > 0 {
+		b.WriteString("\nKept:\n")
+		for _, skip := range plan.skipped {
+			fmt.Fprintf(&b, "  %s: %s\n", skip.name, skip.reason)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func removeCleanTarget(
+	w io.Writer, executor command.Executor, mainRepoPath string, target cleanTarget, withBranch bool,
+) error {
+	path := target.worktree.Path
+	result, err := executor.Execute([]command.Command{command.GitWorktreeRemove(path, target.dirty)})
+	if err != nil {
+		return errors.WorktreeRemovalFailed(path, err)
+	}
+	if len(result.Results) > 0 && result.Results[0].Error != nil {
+		if gitOutput := result.Results[0].Output; gitOutput != "" {
+			return errors.WorktreeRemovalFailed(path, fmt.Errorf("%w: %s", result.Results[0].Error, gitOutput))
+		}
+		return errors.WorktreeRemovalFailed(path, result.Results[0].Error)
+	}
+	if _, err := fmt.Fprintf(w, "Removed worktree '%s' at %s\n", target.name, path); err != nil {
+		return err
+	}
+
+	if !withBranch {
+		recordLeftoverBranch(mainRepoPath, target.worktree)
+		return nil
+	}
+	// Rebased and squashed branches are not merged as far as 'git branch -d' can
+	// tell, and the target may be a remote branch, so deletion is forced.
+	return removeBranchWithCommandExecutor(w, executor, target.worktree.Branch, true)
+}
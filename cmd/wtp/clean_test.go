@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+const cleanTestWorktreeList = `worktree /repo/main
+HEAD abc
+branch refs/heads/main
+
+worktree /repo/worktrees/feature/merged
+HEAD def
+branch refs/heads/feature/merged
+
+worktree /repo/worktrees/feature/squashed
+HEAD ghi
+branch refs/heads/feature/squashed
+
+worktree /repo/worktrees/feature/wip
+HEAD jkl
+branch refs/heads/feature/wip
+
+worktree /repo/worktrees/feature/dirty
+HEAD mno
+branch refs/heads/feature/dirty
+
+worktree /repo/worktrees/feature/usb
+HEAD pqr
+branch refs/heads/feature/usb
+locked
+
+worktree /repo/worktrees/feature/here
+HEAD stu
+branch refs/heads/feature/here
+
+worktree /elsewhere/merged
+HEAD vwx
+branch refs/heads/elsewhere
+`
+
+// stubClean stubs the git queries of clean and returns the branches it records
+// as left over for 'wtp prune'.
+func stubClean(t *testing.T, canPrompt bool) *[]string {
+	t.Helper()
+	originalState, originalDefault := cleanMergeState, cleanDefaultBranch
+	originalStatus, originalPrompt := cleanWorktreeStatus, canPromptForConfirmation
+	originalRecord := removeRecordLeftoverBranch
+	t.Cleanup(func() {
+		cleanMergeState, cleanDefaultBranch = originalState, originalDefault
+		cleanWorktreeStatus, canPromptForConfirmation = originalStatus, originalPrompt
+		removeRecordLeftoverBranch = originalRecord
+	})
+
+	states := map[string]git.MergeState{
+		"feature/merged":   git.Merged,
+		"feature/squashed": git.Squashed,
+		"feature/dirty":    git.Rebased,
+		"feature/usb":      git.Merged,
+		"feature/here":     git.Merged,
+		"elsewhere":        git.Merged,
+	}
+	cleanMergeState = func(repoPath, branch, target string) (git.MergeState, error) {
+		assert.Equal(t, "/repo/main", repoPath)
+		assert.Equal(t, "origin/main", target)
+		return states[branch], nil
+	}
+	cleanDefaultBranch = func(string) string { return "origin/main" }
+	cleanWorktreeStatus = func(path, _ string) (git.Status, error) {
+		if path == "/repo/worktrees/feature/dirty" {
+			return git.Status{Changed: 1}, nil
+		}
+		return git.Status{}, nil
+	}
+	canPromptForConfirmation = func() bool { return canPrompt }
+
+	var leftovers []string
+	removeRecordLeftoverBranch = func(repoPath, branch string) error {
+		assert.Equal(t, "/repo/main", repoPath)
+		leftovers = append(leftovers, branch)
+		return nil
+	}
+	return &leftovers
+}
+
+func runClean(t *testing.T, mock *mockExecCommandExecutor, input string, opts cleanOptions) (string, error) {
+	t.Helper()
+	cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+	var buf bytes.Buffer
+	err := cleanCommandWithCommandExecutor(&buf, strings.NewReader(input), mock, cfg, "/repo/main",
+		"/repo/worktrees/feature/here/src", opts)
+	return buf.String(), err
+}
+
+func newCleanExecutor() *mockExecCommandExecutor {
+	return &mockExecCommandExecutor{
+		results: []*command.ExecutionResult{{Results: []command.Result{{Output: cleanTestWorktreeList}}}},
+	}
+}
+
+func TestCleanCommand_DryRun(t *testing.T) {
+	stubClean(t, true)
+	mock := newCleanExecutor()
+
+	output, err := runClean(t, mock, "", cleanOptions{DryRun: true})
+	require.NoError(t, err)
+
+	expected := "Worktrees with branches merged into origin/main:\n" +
+		"  feature/merged    merged\n" +
+		"  feature/squashed  squash-merged\n" +
+		"\n" +
+		"Kept:\n" +
+		"  feature/dirty: uncommitted changes (use --force to remove anyway)\n" +
+		"  feature/usb: locked\n" +
+		"  feature/here: current worktree\n"
+	assert.Equal(t, expected, output)
+	assert.Len(t, mock.executed, 1)
+}
+
+func TestCleanCommand_Remove(t *testing.T) {
+	t.Run("removes worktrees and branches", func(t *testing.T) {
+		leftovers := stubClean(t, false)
+		mock := newCleanExecutor()
+
+		output, err := runClean(t, mock, "", cleanOptions{Yes: true, WithBranch: true})
+		require.NoError(t, err)
+
+		var executed []command.Command
+		for _, commands := range mock.executed[1:] {
+			executed = append(executed, commands...)
+		}
+		assert.Equal(t, []command.Command{
+			command.GitWorktreeRemove("/repo/worktrees/feature/merged", false),
+			command.GitBranchDelete("feature/merged", true),
+			command.GitWorktreeRemove("/repo/worktrees/feature/squashed", false),
+			command.GitBranchDelete("feature/squashed", true),
+		}, executed)
+		assert.Contains(t, output, "Removed worktree 'feature/squashed' at /repo/worktrees/feature/squashed\n")
+		assert.Contains(t, output, "Removed branch 'feature/squashed'\n")
+		assert.Empty(t, *leftovers)
+	})
+
+	t.Run("records kept branches for prune", func(t *testing.T) {
+		leftovers := stubClean(t, false)
+		mock := newCleanExecutor()
+
+		output, err := runClean(t, mock, "", cleanOptions{Yes: true})
+		require.NoError(t, err)
+		assert.NotContains(t, output, "Removed branch")
+		assert.Equal(t, []string{"feature/merged", "feature/squashed"}, *leftovers)
+	})
+
+	t.Run("force removes dirty worktrees", func(t *testing.T) {
+		stubClean(t, true)
+		mock := newCleanExecutor()
+
+		output, err := runClean(t, mock, "yes\n", cleanOptions{Force: true})
+		require.NoError(t, err)
+		assert.Contains(t, output, "feature/dirty     rebase-merged, uncommitted changes will be lost\n")
+		assert.Contains(t, output, "Remove these worktrees? [y/N]: ")
+		require.Len(t, mock.executed, 4)
+		assert.Equal(t, command.GitWorktreeRemove("/repo/worktrees/feature/dirty", true), mock.executed[3][0])
+	})
+
+	t.Run("keeps everything when declined", func(t *testing.T) {
+		stubClean(t, true)
+		mock := newCleanExecutor()
+
+		output, err := runClean(t, mock, "n\n", cleanOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, output, "Nothing was removed\n")
+		assert.Len(t, mock.executed, 1)
+	})
+
+	t.Run("requires --yes without a terminal", func(t *testing.T) {
+		stubClean(t, false)
+		mock := newCleanExecutor()
+
+		_, err := runClean(t, mock, "", cleanOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "wtp clean --merged --yes")
+		assert.Len(t, mock.executed, 1)
+	})
+}
+
+func TestCleanCommand_Into(t *testing.T) {
+	stubClean(t, true)
+	cleanMergeState = func(_, _, target string) (git.MergeState, error) {
+		assert.Equal(t, "develop", target)
+		return git.NotMerged, nil
+	}
+	cleanDefaultBranch = func(string) string {
+		t.Fatal("the default branch is not needed with --into")
+		return ""
+	}
+
+	output, err := runClean(t, newCleanExecutor(), "", cleanOptions{Into: "develop"})
+	require.NoError(t, err)
+	assert.Equal(t, "No worktrees with branches merged into develop to remove\n", output)
+}
+
+func TestCleanCommand_Errors(t *testing.T) {
+	t.Run("unknown default branch", func(t *testing.T) {
+		stubClean(t, true)
+		cleanDefaultBranch = func(string) string { return "" }
+
+		_, err := runClean(t, newCleanExecutor(), "", cleanOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--into")
+	})
+
+	t.Run("requires --merged", func(t *testing.T) {
+		app := &cli.Command{Name: "wtp", Commands: []*cli.Command{NewCleanCommand()}}
+		err := app.Run(context.Background(), []string{"wtp", "clean"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "use --merged")
+	})
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+const (
+	cloneUsage   = "wtp clone <url-or-path> [<directory>]"
+	bareDirName  = ".bare"
+	cloneRemote  = "origin"
+	cloneRefspec = "+refs/heads/*:refs/remotes/origin/*"
+)
+
+// NewCloneCommand creates the clone command definition
+func NewCloneCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "clone",
+		Usage:     "Clone a repository as a bare repository with a worktree for its default branch",
+		UsageText: cloneUsage,
+		Description: "Clones the repository as a bare repository into <directory>/.bare and adds a worktree " +
+			"for its default branch at <directory>/<branch>. A .git file in <directory> points git at the " +
+			"bare repository, so wtp and git also work from <directory> itself.\n\n" +
+			"The default worktree is recorded with 'git config wtp.defaultWorktree'. In a bare repository it " +
+			"takes the place of the main worktree: '@' refers to it, .wtp.yml is read from it and base_dir " +
+			"is resolved from it, so new worktrees go to <directory>/worktrees by default.\n\n" +
+			"<directory> defaults to the repository name, and must not exist yet.\n\n" +
+			"Examples:\n" +
+			"  wtp clone https://github.com/satococoa/wtp.git       # Clone into ./wtp\n" +
+			"  wtp clone git@github.com:satococoa/wtp.git src/wtp   # Clone into ./src/wtp",
+		Action: cloneCommand,
+	}
+}
+
+func cloneCommand(_ context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return errors.DirectoryAccessFailed("access current", ".", err)
+	}
+
+	return cloneCommandWithCommandExecutor(w, command.NewRealExecutor(), cwd, cmd.Args().Get(0), cmd.Args().Get(1))
+}
+
+func cloneCommandWithCommandExecutor(
+	w io.Writer, executor command.Executor, cwd, source, directory string,
+) (err error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return fmt.Errorf("repository to clone is required\n\nUsage: %s", cloneUsage)
+	}
+
+	directory = strings.TrimSpace(directory)
+	if directory == "" {
+		if directory = cloneDirectoryName(source); directory == "" {
+			return fmt.Errorf("cannot derive a directory name from '%s'; pass one\n\nUsage: %s", source, cloneUsage)
+		}
+	}
+	if !filepath.IsAbs(directory) {
+		directory = filepath.Join(cwd, directory)
+	}
+	if _, statErr := os.Lstat(directory); statErr == nil {
+		return fmt.Errorf("cannot clone into %s: it already exists", directory)
+	}
+
+	// Everything below directory was created by this clone, so a failed clone leaves nothing behind.
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(directory)
+		}
+	}()
+
+	bareDir := filepath.Join(directory, bareDirName)
+	clone := command.GitCloneBare(source, bareDir)
+	clone.WorkDir = cwd
+	if _, err := runGitCommandOutput(executor, clone); err != nil {
+		return err
+	}
+	if err := writeBareGitFile(directory); err != nil {
+		return err
+	}
+
+	branch, err := setupBareClone(executor, bareDir)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Cloned %s into %s\n", source, bareDir); err != nil {
+		return err
+	}
+
+	worktreePath := filepath.Join(directory, branch)
+	if err := runInBareRepo(executor, bareDir,
+		command.GitWorktreeAdd(worktreePath, branch, command.GitWorktreeAddOptions{}),
+		command.GitBranchSetUpstream(branch, cloneRemote+"/"+branch),
+		command.GitConfigSet(git.DefaultWorktreeConfigKey, filepath.Join("..", branch)),
+	); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "Created default worktree '%s' at %s\n", branch, worktreePath)
+	return err
+}
+
+// setupBareClone makes the bare repository fetch remote branches as remote-tracking
+// branches, which 'git clone --bare' skips, and returns the default branch.
+func setupBareClone(executor command.Executor, bareDir string) (string, error) {
+	if err := runInBareRepo(executor, bareDir,
+		command.GitConfigSet("remote."+cloneRemote+".fetch", cloneRefspec),
+		command.GitFetch(cloneRemote),
+	); err != nil {
+		return "", err
+	}
+
+	head := command.GitHeadBranch()
+	head.WorkDir = bareDir
+	branch, err := runGitCommandOutput(executor, head)
+	if err != nil {
+		return "", err
+	}
+	if branch == "" {
+		return "", fmt.Errorf("cannot determine the default branch of the cloned repository")
+	}
+
+	if err := runInBareRepo(executor, bareDir, command.GitRemoteSetHead(cloneRemote, branch)); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
+
+func runInBareRepo(executor command.Executor, bareDir string, commands ...command.Command) error {
+	for _, cmd := range commands {
+		cmd.WorkDir = bareDir
+		if _, err := runGitCommandOutput(executor, cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBareGitFile points git at the bare repository from directory itself.
+func writeBareGitFile(directory string) error {
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return errors.DirectoryAccessFailed("create", directory, err)
+	}
+	gitFile := filepath.Join(directory, ".git")
+	if err := os.WriteFile(gitFile, []byte("gitdir: ./"+bareDirName+"\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", gitFile, err)
+	}
+	return nil
+}
+
+// cloneDirectoryName derives the directory name the way 'git clone' does, e.g.
+// "wtp" from "https://github.com/satococoa/wtp.git" or "git@github.com:satococoa/wtp".
+func cloneDirectoryName(source string) string {
+	name := strings.TrimRight(source, `/\`)
+	name = strings.TrimSuffix(name, "/.git")
+	name = name[strings.LastIndexAny(name, `/\:`)+1:]
+	return strings.TrimSuffix(name, ".git")
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+)
+
+func TestNewCloneCommand(t *testing.T) {
+	cmd := NewCloneCommand()
+	assert.Equal(t, "clone", cmd.Name)
+	assert.NotNil(t, cmd.Action)
+}
+
+func TestCloneCommandWithCommandExecutor(t *testing.T) {
+	t.Run("sets up the bare layout", func(t *testing.T) {
+		cwd := t.TempDir()
+		mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{
+			{Results: []command.Result{{}}},
+			{Results: []command.Result{{}}},
+			{Results: []command.Result{{}}},
+			{Results: []command.Result{{Output: "develop\n"}}},
+		}}
+
+		var buf bytes.Buffer
+		err := cloneCommandWithCommandExecutor(&buf, mock, cwd, "https://example.com/team/app.git", "")
+		require.NoError(t, err)
+
+		dir := filepath.Join(cwd, "app")
+		bareDir := filepath.Join(dir, ".bare")
+		inBare := func(cmd command.Command) command.Command {
+			cmd.WorkDir = bareDir
+			return cmd
+		}
+		clone := command.GitCloneBare("https://example.com/team/app.git", bareDir)
+		clone.WorkDir = cwd
+
+		var executed []command.Command
+		for _, commands := range mock.executed {
+			executed = append(executed, commands...)
+		}
+		assert.Equal(t, []command.Command{
+			clone,
+			inBare(command.GitConfigSet("remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")),
+			inBare(command.GitFetch("origin")),
+			inBare(command.GitHeadBranch()),
+			inBare(command.GitRemoteSetHead("origin", "develop")),
+			inBare(command.GitWorktreeAdd(filepath.Join(dir, "develop"), "develop", command.GitWorktreeAddOptions{})),
+			inBare(command.GitBranchSetUpstream("develop", "origin/develop")),
+			inBare(command.GitConfigSet("wtp.defaultWorktree", filepath.Join("..", "develop"))),
+		}, executed)
+
+		content, err := os.ReadFile(filepath.Join(dir, ".git"))
+		require.NoError(t, err)
+		assert.Equal(t, "gitdir: ./.bare\n", string(content))
+		assert.Equal(t, "Cloned https://example.com/team/app.git into "+bareDir+"\n"+
+			"Created default worktree 'develop' at "+filepath.Join(dir, "develop")+"\n", buf.String())
+	})
+
+	t.Run("refuses an existing directory", func(t *testing.T) {
+		cwd := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(cwd, "app"), 0o750))
+		mock := &mockExecCommandExecutor{}
+
+		err := cloneCommandWithCommandExecutor(&bytes.Buffer{}, mock, cwd, "../app", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+		assert.Empty(t, mock.executed)
+	})
+
+	t.Run("removes the directory when a step fails", func(t *testing.T) {
+		cwd := t.TempDir()
+		mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{
+			{Results: []command.Result{{}}},
+			{Results: []command.Result{{}}},
+			{Results: []command.Result{{Output: "fatal: could not read from remote repository", Error: assert.AnError}}},
+		}}
+
+		err := cloneCommandWithCommandExecutor(&bytes.Buffer{}, mock, cwd, "git@example.com:app", "work/app")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "could not read from remote repository")
+		assert.NoDirExists(t, filepath.Join(cwd, "work", "app"))
+	})
+
+	t.Run("requires a repository", func(t *testing.T) {
+		err := cloneCommandWithCommandExecutor(&bytes.Buffer{}, &mockExecCommandExecutor{}, t.TempDir(), " ", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Usage: wtp clone")
+	})
+}
+
+func TestCloneDirectoryName(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/satococoa/wtp.git": "wtp",
+		"https://github.com/satococoa/wtp/":    "wtp",
+		"git@github.com:satococoa/wtp.git":     "wtp",
+		"git@example.com:wtp":                  "wtp",
+		"../src/wtp/.git":                      "wtp",
+		"/srv/git/wtp.git":                     "wtp",
+		"/":                                    "",
+	}
+	for source, expected := range tests {
+		assert.Equal(t, expected, cloneDirectoryName(source), source)
+	}
+}
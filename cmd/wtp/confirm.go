@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var canPromptForConfirmation = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// confirmAction asks a yes/no question before a destructive or trusting action. Without a
+// terminal to ask on it fails with hint, which should name the flag that skips
+// the question.
+func confirmAction(w io.Writer, in io.Reader, question, hint string) (bool, error) {
+	if !canPromptForConfirmation() {
+		return false, fmt.Errorf("refusing to continue without confirmation; %s", hint)
+	}
+	if _, err := fmt.Fprintf(w, "\n%s [y/N]: ", question); err != nil {
+		return false, err
+	}
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
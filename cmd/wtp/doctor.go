@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/hooks"
+)
+
+// NewDoctorCommand creates the doctor command definition
+func NewDoctorCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "Check worktrees for common problems",
+		Description: "Inspects every worktree and reports problems that wtp can detect, such as " +
+			"dangling symlinks created by symlink hooks (for example after moving the repository).",
+		Action: doctorCommand,
+	}
+}
+
+func doctorCommand(_ context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	_, cfg, mainRepoPath, err := setupRepoAndConfig()
+	if err != nil {
+		return err
+	}
+
+	executor := command.NewRealExecutor()
+	return doctorCommandWithCommandExecutor(w, executor, cfg, mainRepoPath)
+}
+
+func doctorCommandWithCommandExecutor(
+	w io.Writer,
+	executor command.Executor,
+	cfg *config.Config,
+	mainRepoPath string,
+) error {
+	worktrees, err := listWorktreesWithExecutor(executor)
+	if err != nil {
+		return err
+	}
+
+	hookExecutor := hooks.NewExecutor(cfg, mainRepoPath)
+
+	var dangling []hooks.DanglingSymlink
+	for i := range worktrees {
+		wt := &worktrees[i]
+		if wt.IsMain {
+			continue
+		}
+
+		found, err := hookExecutor.FindDanglingSymlinks(wt.Path)
+		if err != nil {
+			return err
+		}
+		dangling = append(dangling, found...)
+	}
+
+	if len(dangling) == 0 {
+		_, err := fmt.Fprintln(w, "✓ No dangling symlinks found")
+		return err
+	}
+
+	for _, link := range dangling {
+		name := getWorktreeNameFromPath(link.WorktreePath, cfg, mainRepoPath, false)
+		relLink, relErr := filepath.Rel(link.WorktreePath, link.LinkPath)
+		if relErr != nil {
+			relLink = link.LinkPath
+		}
+		if _, err := fmt.Fprintf(w, "✗ %s: %s → %s (target missing, hook %d)\n",
+			name, relLink, link.Target, link.HookIndex+1); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf(`found %d dangling symlink(s)
+
+Tip: Restore the symlink sources in the main worktree, or delete the links and
+re-create them with 'relative: true' if the repository was moved`, len(dangling))
+}
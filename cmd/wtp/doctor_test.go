@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func TestNewDoctorCommand(t *testing.T) {
+	cmd := NewDoctorCommand()
+	assert.Equal(t, "doctor", cmd.Name)
+	assert.NotNil(t, cmd.Action)
+}
+
+func TestDoctorCommand_ReportsDanglingSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink creation requires elevated privileges on Windows")
+	}
+
+	tempDir := t.TempDir()
+	mainRepoPath := filepath.Join(tempDir, "repo")
+	worktreePath := filepath.Join(tempDir, "worktrees", "feature", "auth")
+	require.NoError(t, os.MkdirAll(mainRepoPath, 0o755))
+	require.NoError(t, os.MkdirAll(worktreePath, 0o755))
+
+	cfg := &config.Config{
+		Defaults: config.Defaults{BaseDir: "../worktrees"},
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeSymlink, From: ".bin", To: ".bin"},
+			},
+		},
+	}
+
+	worktreeList := "worktree " + mainRepoPath + "\nHEAD abc123\nbranch refs/heads/main\n\n" +
+		"worktree " + worktreePath + "\nHEAD def456\nbranch refs/heads/feature/auth\n\n"
+	newExecutor := func() *mockListCommandExecutor {
+		return &mockListCommandExecutor{
+			results: []command.Result{{Output: worktreeList}},
+		}
+	}
+
+	t.Run("healthy worktrees", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := doctorCommandWithCommandExecutor(&buf, newExecutor(), cfg, mainRepoPath)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "No dangling symlinks found")
+	})
+
+	t.Run("dangling symlink is reported", func(t *testing.T) {
+		missingTarget := filepath.Join(mainRepoPath, ".bin")
+		require.NoError(t, os.Symlink(missingTarget, filepath.Join(worktreePath, ".bin")))
+
+		var buf bytes.Buffer
+		err := doctorCommandWithCommandExecutor(&buf, newExecutor(), cfg, mainRepoPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "found 1 dangling symlink(s)")
+		assert.Contains(t, buf.String(), "feature/auth: .bin → "+missingTarget)
+	})
+}
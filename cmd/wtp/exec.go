@@ -52,25 +52,11 @@ func execCommandWithCommandExecutor(cmd *cli.Command, w io.Writer, executor comm
 		return err
 	}
 
-	result, err := executor.Execute([]command.Command{command.GitWorktreeList()})
+	worktrees, err := listWorktreesWithExecutor(executor)
 	if err != nil {
-		return errors.GitCommandFailed("git worktree list", err.Error())
-	}
-
-	if len(result.Results) == 0 {
-		return errors.GitCommandFailed("git worktree list", "no command results")
-	}
-
-	gitResult := result.Results[0]
-	if gitResult.Error != nil {
-		msg := gitResult.Error.Error()
-		if gitResult.Output != "" {
-			msg = msg + ": " + gitResult.Output
-		}
-		return errors.GitCommandFailed("git worktree list", msg)
+		return err
 	}
 
-	worktrees := parseWorktreesFromOutput(gitResult.Output)
 	mainWorktreePath := findMainWorktreePath(worktrees)
 	targetPath := resolveWorktreePathByName(worktreeName, worktrees, mainWorktreePath)
 	if targetPath == "" {
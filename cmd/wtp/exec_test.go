@@ -185,3 +185,9 @@ func (m *mockExecCommandExecutor) Execute(commands []command.Command) (*command.
 
 	return &command.ExecutionResult{}, nil
 }
+
+// commandInDir returns cmd as run in dir, for comparing with the commands a mock executor recorded.
+func commandInDir(cmd command.Command, dir string) command.Command {
+	cmd.WorkDir = dir
+	return cmd
+}
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/git"
+	"github.com/satococoa/wtp/v2/internal/hooks"
+)
+
+var hookTypes = []string{config.HookTypeCopy, config.HookTypeCommand, config.HookTypeSymlink}
+
+// NewHooksCommand creates the hooks command definition
+func NewHooksCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "hooks",
+		Usage: "Work with post-create hooks from .wtp.yml",
+		Commands: []*cli.Command{
+			newHooksRunCommand(),
+			newHooksPlanCommand(),
+		},
+	}
+}
+
+func newHooksRunCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "run",
+		Usage: "Run post-create hooks against an existing worktree",
+		UsageText: "wtp hooks run [<worktree>] [--only <hook>]... [--type <type>]\n" +
+			"       wtp hooks run --all [--only <hook>]... [--type <type>]",
+		Description: "Executes the post_create hooks configured in .wtp.yml against a worktree that already " +
+			"exists. The worktree is resolved the same way as 'wtp cd'; without an argument the current " +
+			"worktree is used. Hooks are numbered from 1 in the order they appear in .wtp.yml.\n\n" +
+			"Examples:\n" +
+			"  wtp hooks run feature/auth              # Re-apply all hooks\n" +
+			"  wtp hooks run feature/auth --only 3     # Run only the third hook\n" +
+			"  wtp hooks run --all --type copy         # Run copy hooks in every managed worktree",
+		ArgsUsage:     "[worktree-name]",
+		ShellComplete: completeWorktrees,
+		Flags: append(hookSelectionFlags(),
+			&cli.BoolFlag{
+				Name:  "trust",
+				Usage: "Run command hooks without checking that they were trusted with 'wtp trust'",
+			},
+			&cli.StringFlag{
+				Name:  "timings",
+				Usage: "Write hook timings of every worktree as JSON to the given file",
+			},
+		),
+		Action: hooksSubcommandAction(hooksRunWithCommandExecutor),
+	}
+}
+
+func newHooksPlanCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "plan",
+		Usage: "Show what post-create hooks would do without running them",
+		UsageText: "wtp hooks plan [<worktree>] [--only <hook>]... [--type <type>]\n" +
+			"       wtp hooks plan --all [--only <hook>]... [--type <type>]",
+		Description: "Resolves every post-create hook against a worktree and prints the absolute source and " +
+			"destination of copy and symlink hooks, the command, work dir and environment of command hooks, " +
+			"and whether each hook is selected and expected to succeed. Nothing is written to disk.",
+		ArgsUsage:     "[worktree-name]",
+		ShellComplete: completeWorktrees,
+		Flags:         hookSelectionFlags(),
+		Action:        hooksSubcommandAction(hooksPlanWithCommandExecutor),
+	}
+}
+
+func hookSelectionFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "only",
+			Usage: "Select only the given hook number, id or name (repeatable)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "skip",
+			Usage: "Skip the given hook number, id or name (repeatable)",
+		},
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "Select only hooks of the given type (copy, command, symlink)",
+		},
+		&cli.BoolFlag{
+			Name:  "all",
+			Usage: "Target every managed worktree except the main worktree",
+		},
+	}
+}
+
+// hooksSubcommandFunc implements a hooks subcommand against the resolved repository state.
+type hooksSubcommandFunc func(
+	cmd *cli.Command, w io.Writer, executor command.Executor, cfg *config.Config, mainRepoPath, cwd string,
+) error
+
+func hooksSubcommandAction(fn hooksSubcommandFunc) cli.ActionFunc {
+	return func(_ context.Context, cmd *cli.Command) error {
+		w := cmd.Root().Writer
+		if w == nil {
+			w = os.Stdout
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			return errors.DirectoryAccessFailed("access current", ".", err)
+		}
+
+		_, cfg, mainRepoPath, err := setupRepoAndConfig()
+		if err != nil {
+			return err
+		}
+
+		executor := command.NewRealExecutor()
+		return fn(cmd, w, executor, cfg, mainRepoPath, cwd)
+	}
+}
+
+// resolveHookSelection validates the hook selection flags and resolves the target worktrees.
+// It returns no targets when there are no hooks to run.
+func resolveHookSelection(
+	cmd *cli.Command,
+	w io.Writer,
+	executor command.Executor,
+	cfg *config.Config,
+	mainRepoPath string,
+	cwd string,
+) (filter func(int, *config.Hook) bool, targets []git.Worktree, err error) {
+	if !cfg.HasHooks() {
+		_, err := fmt.Fprintln(w, "No post-create hooks configured in .wtp.yml")
+		return nil, nil, err
+	}
+
+	filter, err = buildHookFilter(cfg, cmd.StringSlice("only"), cmd.StringSlice("skip"), cmd.String("type"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !selectsAnyHook(cfg, filter) {
+		return nil, nil, fmt.Errorf("no hooks in .wtp.yml match the given selection")
+	}
+
+	targets, err = resolveHookTargets(cmd, executor, cfg, mainRepoPath, cwd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return filter, targets, nil
+}
+
+func hooksRunWithCommandExecutor(
+	cmd *cli.Command,
+	w io.Writer,
+	executor command.Executor,
+	cfg *config.Config,
+	mainRepoPath string,
+	cwd string,
+) error {
+	filter, targets, err := resolveHookSelection(cmd, w, executor, cfg, mainRepoPath, cwd)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) > 0 && !cmd.Bool("trust") && selectsCommandHook(cfg, filter) {
+		if err := ensureHooksTrusted(w, os.Stdin, cfg, mainRepoPath, "wtp hooks run"); err != nil {
+			return err
+		}
+	}
+
+	reports, runErr := runHooksInTargets(w, cfg, mainRepoPath, filter, targets)
+	if timingsPath := cmd.String("timings"); timingsPath != "" {
+		if err := writeTimingReports(timingsPath, reports); err != nil {
+			return err
+		}
+	}
+	return runErr
+}
+
+// runHooksInTargets runs the selected hooks in every target worktree and
+// returns their timing reports, including those of worktrees where hooks failed.
+func runHooksInTargets(
+	w io.Writer,
+	cfg *config.Config,
+	mainRepoPath string,
+	filter func(int, *config.Hook) bool,
+	targets []git.Worktree,
+) ([]hooks.TimingReport, error) {
+	var failed []string
+	var reports []hooks.TimingReport
+	for i := range targets {
+		target := &targets[i]
+		name := getWorktreeNameFromPath(target.Path, cfg, mainRepoPath, false)
+		if _, err := fmt.Fprintf(w, "Running hooks in '%s' (%s)\n", name, target.Path); err != nil {
+			return reports, err
+		}
+
+		opts := hooks.Options{Filter: filter, Target: existingHookTarget(target, name)}
+		run, err := executePostCreateHooksWithOptions(w, cfg, mainRepoPath, target.Path, opts)
+		if run.report != nil {
+			reports = append(reports, *run.report)
+		}
+		if err != nil {
+			if len(targets) == 1 {
+				return reports, fmt.Errorf("hooks failed in worktree '%s': %w", name, err)
+			}
+			if _, warnErr := fmt.Fprintf(w, "Warning: Hook execution failed: %v\n", err); warnErr != nil {
+				return reports, warnErr
+			}
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return reports, fmt.Errorf("hooks failed in %d worktree(s): %s", len(failed), strings.Join(failed, ", "))
+	}
+
+	return reports, nil
+}
+
+func hooksPlanWithCommandExecutor(
+	cmd *cli.Command,
+	w io.Writer,
+	executor command.Executor,
+	cfg *config.Config,
+	mainRepoPath string,
+	cwd string,
+) error {
+	filter, targets, err := resolveHookSelection(cmd, w, executor, cfg, mainRepoPath, cwd)
+	if err != nil {
+		return err
+	}
+
+	var planErr error
+	for i := range targets {
+		target := &targets[i]
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		name := getWorktreeNameFromPath(target.Path, cfg, mainRepoPath, false)
+		if _, err := fmt.Fprintf(w, "Hook plan for '%s' (%s)\n", name, target.Path); err != nil {
+			return err
+		}
+		opts := hooks.Options{Filter: filter, Target: existingHookTarget(target, name)}
+		hookExecutor := hooks.NewExecutorWithOptions(cfg, mainRepoPath, opts)
+		if err := hookExecutor.PlanPostCreateHooks(w, target.Path); err != nil && planErr == nil {
+			planErr = fmt.Errorf("worktree '%s': %w", name, err)
+		}
+	}
+
+	return planErr
+}
+
+// buildHookFilter converts hook selections into a hook filter: only and skip hold
+// hook selectors (see resolveHookSelector) and hookType limits hooks to one type.
+func buildHookFilter(
+	cfg *config.Config, only, skip []string, hookType string,
+) (func(int, *config.Hook) bool, error) {
+	if hookType != "" && !slices.Contains(hookTypes, hookType) {
+		return nil, fmt.Errorf("invalid hook type '%s', must be one of: %s", hookType, strings.Join(hookTypes, ", "))
+	}
+
+	selected, err := resolveHookSelectors(cfg, only)
+	if err != nil {
+		return nil, err
+	}
+	skipped, err := resolveHookSelectors(cfg, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(index int, hook *config.Hook) bool {
+		if len(selected) > 0 && !selected[index] {
+			return false
+		}
+		if skipped[index] {
+			return false
+		}
+		return hookType == "" || hook.Type == hookType
+	}, nil
+}
+
+// resolveHookSelectors returns the zero-based indexes of the hooks named by selectors.
+func resolveHookSelectors(cfg *config.Config, selectors []string) (map[int]bool, error) {
+	indexes := make(map[int]bool, len(selectors))
+	for _, selector := range selectors {
+		matched, err := resolveHookSelector(cfg, strings.TrimSpace(selector))
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range matched {
+			indexes[index] = true
+		}
+	}
+	return indexes, nil
+}
+
+// resolveHookSelector returns the zero-based indexes of the hooks a selector names:
+// a hook number counted from 1, a hook id, or a hook name.
+func resolveHookSelector(cfg *config.Config, selector string) ([]int, error) {
+	total := len(cfg.Hooks.PostCreate)
+	if number, err := strconv.Atoi(selector); err == nil {
+		if number < 1 || number > total {
+			return nil, fmt.Errorf("invalid hook selector '%s': expected a hook number between 1 and %d", selector, total)
+		}
+		return []int{number - 1}, nil
+	}
+
+	var matched []int
+	for i := range cfg.Hooks.PostCreate {
+		hook := &cfg.Hooks.PostCreate[i]
+		if selector != "" && (hook.ID == selector || hook.Name == selector) {
+			matched = append(matched, i)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("invalid hook selector '%s': no hook in .wtp.yml has this id or name "+
+			"(expected a hook number between 1 and %d, an id or a name)", selector, total)
+	}
+	return matched, nil
+}
+
+// selectsAnyHook reports whether the filter selects at least one hook. A nil filter selects every hook.
+func selectsAnyHook(cfg *config.Config, filter func(int, *config.Hook) bool) bool {
+	for i := range cfg.Hooks.PostCreate {
+		if filter == nil || filter(i, &cfg.Hooks.PostCreate[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectsCommandHook reports whether the filter selects at least one command hook.
+// A nil filter selects every hook.
+func selectsCommandHook(cfg *config.Config, filter func(int, *config.Hook) bool) bool {
+	for i := range cfg.Hooks.PostCreate {
+		hook := &cfg.Hooks.PostCreate[i]
+		if hook.Type == config.HookTypeCommand && (filter == nil || filter(i, hook)) {
+			return true
+		}
+	}
+	return false
+}
+
+// existingHookTarget describes an existing worktree to command hooks. How it
+// was created is unknown, so BaseRef and Remote stay empty.
+func existingHookTarget(wt *git.Worktree, name string) hooks.Target {
+	target := hooks.Target{Name: name, Head: wt.HEAD}
+	if wt.Branch != detachedKeyword {
+		target.Branch = wt.Branch
+	}
+	return target
+}
+
+// resolveHookTargets returns the worktrees that hooks should be applied to.
+func resolveHookTargets(
+	cmd *cli.Command,
+	executor command.Executor,
+	cfg *config.Config,
+	mainRepoPath string,
+	cwd string,
+) ([]git.Worktree, error) {
+	worktrees, err := listWorktreesWithExecutor(executor)
+	if err != nil {
+		return nil, err
+	}
+	mainWorktreePath := findMainWorktreePath(worktrees)
+
+	if cmd.Bool("all") {
+		if cmd.Args().Len() > 0 {
+			return nil, fmt.Errorf("--all cannot be combined with a worktree name")
+		}
+		var targets []git.Worktree
+		for i := range worktrees {
+			wt := &worktrees[i]
+			if !wt.IsMain && !wt.Bare && isWorktreeManagedCommon(wt.Path, cfg, mainRepoPath, wt.IsMain) {
+				targets = append(targets, *wt)
+			}
+		}
+		if len(targets) == 0 {
+			return nil, fmt.Errorf("no managed worktrees found")
+		}
+		return targets, nil
+	}
+
+	var target *git.Worktree
+	worktreeName := cmd.Args().First()
+	if worktreeName != "" {
+		targetPath := resolveWorktreePathByName(worktreeName, worktrees, mainWorktreePath)
+		target = findWorktreeByPath(worktrees, targetPath)
+		if target == nil {
+			return nil, errors.WorktreeNotFound(worktreeName, availableManagedWorktreeNames(worktrees, mainWorktreePath))
+		}
+	} else {
+		target = findWorktreeContaining(worktrees, cwd)
+		if target == nil {
+			return nil, fmt.Errorf("current directory is not inside a worktree; specify a worktree name")
+		}
+	}
+
+	if isMainWorktree(target.Path, mainWorktreePath) {
+		return nil, fmt.Errorf("hooks cannot be run against the main worktree; hook sources are read from it\n\n" +
+			"Tip: Run 'wtp hooks run <worktree>' from the main worktree")
+	}
+
+	return []git.Worktree{*target}, nil
+}
+
+// findWorktreeByPath returns the worktree listed at exactly path, or nil.
+func findWorktreeByPath(worktrees []git.Worktree, path string) *git.Worktree {
+	if path == "" {
+		return nil
+	}
+	for i := range worktrees {
+		if worktrees[i].Path == path {
+			return &worktrees[i]
+		}
+	}
+	return nil
+}
+
+// findWorktreeContaining returns the worktree whose directory contains path (deepest match wins).
+func findWorktreeContaining(worktrees []git.Worktree, path string) *git.Worktree {
+	resolvedPath := path
+	if evaluated, err := filepath.EvalSymlinks(path); err == nil {
+		resolvedPath = evaluated
+	}
+
+	var best *git.Worktree
+	for i := range worktrees {
+		wt := &worktrees[i]
+		wtPath := wt.Path
+		if evaluated, err := filepath.EvalSymlinks(wtPath); err == nil {
+			wtPath = evaluated
+		}
+		if !isPathWithin(wtPath, resolvedPath) && !isPathWithin(wt.Path, path) {
+			continue
+		}
+		if best == nil || len(wt.Path) > len(best.Path) {
+			best = wt
+		}
+	}
+
+	return best
+}
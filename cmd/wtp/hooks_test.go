@@ -0,0 +1,330 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/hooks"
+)
+
+// ===== Command Structure Tests =====
+
+func TestNewHooksCommand(t *testing.T) {
+	cmd := NewHooksCommand()
+	assert.Equal(t, "hooks", cmd.Name)
+	require.Len(t, cmd.Commands, 2)
+	assert.Equal(t, "plan", cmd.Commands[1].Name)
+
+	run := cmd.Commands[0]
+	assert.Equal(t, "run", run.Name)
+	assert.NotNil(t, run.Action)
+	assert.NotNil(t, run.ShellComplete)
+
+	flagNames := make(map[string]bool)
+	for _, flag := range run.Flags {
+		flagNames[flag.Names()[0]] = true
+	}
+	assert.True(t, flagNames["only"])
+	assert.True(t, flagNames["type"])
+	assert.True(t, flagNames["all"])
+}
+
+// ===== Command Execution Tests =====
+
+func TestHooksRun_Execution(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		cwd            string
+		hookIDs        []string
+		hookNames      []string
+		expectedFiles  []string
+		missingFiles   []string
+		expectedOutput []string
+		missingOutput  []string
+	}{
+		{
+			name:           "runs all hooks in the named worktree",
+			args:           []string{"feature/auth"},
+			cwd:            "repo",
+			expectedFiles:  []string{"worktrees/feature/auth/.env", "worktrees/feature/auth/.tool"},
+			missingFiles:   []string{"worktrees/other/.env"},
+			expectedOutput: []string{"Running hooks in 'feature/auth'"},
+		},
+		{
+			name:          "defaults to the current worktree",
+			cwd:           "worktrees/other",
+			expectedFiles: []string{"worktrees/other/.env"},
+		},
+		{
+			name:           "--only selects hooks by number",
+			args:           []string{"--only", "2", "feature/auth"},
+			cwd:            "repo",
+			expectedFiles:  []string{"worktrees/feature/auth/.tool"},
+			missingFiles:   []string{"worktrees/feature/auth/.env"},
+			expectedOutput: []string{"Running hook 2 of 2"},
+			missingOutput:  []string{"Running hook 1 of 2"},
+		},
+		{
+			name:           "--skip selects hooks by name",
+			args:           []string{"--skip", "Tool config", "feature/auth"},
+			cwd:            "repo",
+			hookIDs:        []string{"env", ""},
+			hookNames:      []string{"", "Tool config"},
+			expectedFiles:  []string{"worktrees/feature/auth/.env"},
+			missingFiles:   []string{"worktrees/feature/auth/.tool"},
+			expectedOutput: []string{"Running hook 1 of 2 (env)"},
+		},
+		{
+			name:          "--all runs in every managed worktree",
+			args:          []string{"--all", "--type", "copy"},
+			cwd:           "repo",
+			expectedFiles: []string{"worktrees/feature/auth/.env", "worktrees/other/.env"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupHooksTestRepo(t)
+			cfg := createHooksTestConfig()
+			for i, id := range tt.hookIDs {
+				cfg.Hooks.PostCreate[i].ID = id
+			}
+			for i, name := range tt.hookNames {
+				cfg.Hooks.PostCreate[i].Name = name
+			}
+			worktreeList := fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+				"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n\n"+
+				"worktree %[1]s/worktrees/other\nHEAD 123\nbranch refs/heads/other\n\n", root)
+
+			output, err := runHooksSubcommand(t, newHooksRunCommand(), hooksRunWithCommandExecutor,
+				cfg, worktreeList, root, filepath.Join(root, tt.cwd), tt.args)
+
+			require.NoError(t, err)
+			for _, file := range tt.expectedFiles {
+				assert.FileExists(t, filepath.Join(root, file))
+			}
+			for _, file := range tt.missingFiles {
+				assert.NoFileExists(t, filepath.Join(root, file))
+			}
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, output, expected)
+			}
+			for _, unexpected := range tt.missingOutput {
+				assert.NotContains(t, output, unexpected)
+			}
+		})
+	}
+}
+
+func TestHooksRun_Timings(t *testing.T) {
+	root := setupHooksTestRepo(t)
+	worktreeList := fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+		"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n\n"+
+		"worktree %[1]s/worktrees/other\nHEAD 123\nbranch refs/heads/other\n\n", root)
+	timingsPath := filepath.Join(t.TempDir(), "timings.json")
+
+	output, err := runHooksSubcommand(t, newHooksRunCommand(), hooksRunWithCommandExecutor,
+		createHooksTestConfig(), worktreeList, root, filepath.Join(root, "repo"),
+		[]string{"--all", "--only", "1", "--timings", timingsPath})
+
+	require.NoError(t, err)
+	assert.Contains(t, output, "Hook timings:")
+
+	data, err := os.ReadFile(timingsPath)
+	require.NoError(t, err)
+	var reports []hooks.TimingReport
+	require.NoError(t, json.Unmarshal(data, &reports))
+	require.Len(t, reports, 2)
+	assert.Equal(t, filepath.Join(root, "worktrees/feature/auth"), reports[0].Worktree)
+	assert.Equal(t, filepath.Join(root, "worktrees/other"), reports[1].Worktree)
+	require.Len(t, reports[0].Hooks, 2)
+	assert.Equal(t, hooks.TimingOK, reports[0].Hooks[0].Status)
+	assert.Equal(t, hooks.TimingSkipped, reports[0].Hooks[1].Status)
+}
+
+func TestHooksPlan_Execution(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		commandHook    bool
+		expectedOutput []string
+	}{
+		{
+			name: "prints resolved paths without touching disk",
+			args: []string{"--only", "1", "feature/auth"},
+			expectedOutput: []string{
+				"Hook plan for 'feature/auth'",
+				"repo/.env (exists)",
+				"worktrees/feature/auth/.env (will be created)",
+				"Hook 2 of 2 (copy): skipped, not selected",
+			},
+		},
+		{
+			name:        "describes the worktree to command hooks",
+			args:        []string{"--only", "3", "feature/auth"},
+			commandHook: true,
+			expectedOutput: []string{
+				"GIT_WTP_WORKTREE_NAME=feature/auth",
+				"GIT_WTP_BRANCH=feature/auth",
+				"GIT_WTP_HEAD=def",
+				"GIT_WTP_IS_NEW_BRANCH=false",
+				"GIT_WTP_HOOK_INDEX=3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupHooksTestRepo(t)
+			cfg := createHooksTestConfig()
+			if tt.commandHook {
+				cfg.Hooks.PostCreate = append(cfg.Hooks.PostCreate,
+					config.Hook{Type: config.HookTypeCommand, Command: "make"})
+			}
+			worktreeList := fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+				"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n\n", root)
+
+			output, err := runHooksSubcommand(t, newHooksPlanCommand(), hooksPlanWithCommandExecutor,
+				cfg, worktreeList, root, filepath.Join(root, "repo"), tt.args)
+
+			require.NoError(t, err)
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, output, expected)
+			}
+			assert.NoFileExists(t, filepath.Join(root, "worktrees/feature/auth/.env"))
+		})
+	}
+}
+
+// ===== Error Handling Tests =====
+
+func TestHooksRun_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		hookIDs       []string
+		expectedError string
+	}{
+		{
+			name:          "rejects the main worktree",
+			args:          []string{"@"},
+			expectedError: "main worktree",
+		},
+		{
+			name:          "rejects selections that match no hooks",
+			args:          []string{"--type", "command", "feature/auth"},
+			expectedError: "no hooks in .wtp.yml match",
+		},
+		{
+			name:          "rejects selecting and skipping the same hook",
+			args:          []string{"--only", "env", "--skip", "env", "feature/auth"},
+			hookIDs:       []string{"env"},
+			expectedError: "no hooks in .wtp.yml match",
+		},
+		{
+			name:          "rejects out of range hook numbers",
+			args:          []string{"--only", "5", "feature/auth"},
+			expectedError: "between 1 and 2",
+		},
+		{
+			name:          "rejects unknown hook ids",
+			args:          []string{"--only", "lint", "feature/auth"},
+			expectedError: "no hook in .wtp.yml has this id or name",
+		},
+		{
+			name:          "unknown worktree",
+			args:          []string{"missing"},
+			expectedError: "worktree 'missing' not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupHooksTestRepo(t)
+			cfg := createHooksTestConfig()
+			for i, id := range tt.hookIDs {
+				cfg.Hooks.PostCreate[i].ID = id
+			}
+			worktreeList := fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+				"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n\n", root)
+
+			_, err := runHooksSubcommand(t, newHooksRunCommand(), hooksRunWithCommandExecutor,
+				cfg, worktreeList, root, filepath.Join(root, "repo"), tt.args)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestHooksPlan_FailingHook(t *testing.T) {
+	root := setupHooksTestRepo(t)
+	require.NoError(t, os.Remove(filepath.Join(root, "repo", ".tool")))
+	worktreeList := fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+		"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n\n", root)
+
+	output, err := runHooksSubcommand(t, newHooksPlanCommand(), hooksPlanWithCommandExecutor,
+		createHooksTestConfig(), worktreeList, root, filepath.Join(root, "repo"), []string{"feature/auth"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 hook(s) would fail")
+	assert.Contains(t, output, "Would fail: source path does not exist")
+}
+
+// ===== Helper Functions =====
+
+// setupHooksTestRepo creates the main worktree at <root>/repo with the files the
+// copy hooks of createHooksTestConfig read, and the directories of two worktrees.
+func setupHooksTestRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range []string{"repo", "worktrees/feature/auth", "worktrees/other"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o750))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "repo", ".env"), []byte("A=1"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "repo", ".tool"), []byte("B=2"), 0o600))
+	return root
+}
+
+func createHooksTestConfig() *config.Config {
+	return &config.Config{
+		Defaults: config.Defaults{BaseDir: "../worktrees"},
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeCopy, From: ".env", To: ".env"},
+				{Type: config.HookTypeCopy, From: ".tool", To: ".tool"},
+			},
+		},
+	}
+}
+
+func runHooksSubcommand(
+	t *testing.T, sub *cli.Command, fn hooksSubcommandFunc, cfg *config.Config,
+	worktreeList, root, cwd string, args []string,
+) (string, error) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var runErr error
+	sub.Action = func(_ context.Context, cmd *cli.Command) error {
+		executor := &mockListCommandExecutor{results: []command.Result{{Output: worktreeList}}}
+		runErr = fn(cmd, &buf, executor, cfg, filepath.Join(root, "repo"), cwd)
+		return nil
+	}
+
+	app := &cli.Command{Name: "wtp", Commands: []*cli.Command{{Name: "hooks", Commands: []*cli.Command{sub}}}}
+	require.NoError(t, app.Run(context.Background(), append([]string{"wtp", "hooks", sub.Name}, args...)))
+	return buf.String(), runErr
+}
@@ -46,8 +46,9 @@ var (
 	listNewRepository = func(path string) (GitRepository, error) {
 		return git.NewRepository(path)
 	}
-	listNewExecutor  = command.NewRealExecutor // Add this for mocking
-	getTerminalWidth = func() int {
+	listNewExecutor   = command.NewRealExecutor // Add this for mocking
+	listSparseProfile = git.ReadSparseProfile
+	getTerminalWidth  = func() int {
 		width, _, err := term.GetSize(int(os.Stdout.Fd()))
 		if err != nil || width <= 0 {
 			return 80 //nolint:mnd // Default terminal width
@@ -59,10 +60,17 @@ var (
 // NewListCommand creates the list command definition
 func NewListCommand() *cli.Command {
 	return &cli.Command{
-		Name:          "list",
-		Aliases:       []string{"ls"},
-		Usage:         "List all worktrees",
-		Description:   "Shows all worktrees with their paths, branches, and HEAD commits.",
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Usage:   "List all worktrees",
+		Description: "Shows all worktrees with their paths, branches, and HEAD commits.\n\n" +
+			"STATUS names the sparse-checkout profile of worktrees created with 'wtp add --sparse'.\n\n" +
+			"With --status, also shows uncommitted changes (~changed ?untracked), commits ahead/behind " +
+			"the upstream and the default branch, and the age and subject of the last commit.\n\n" +
+			"With --format, prints name, path, branch, head, detached, is_main, managed, current, bare, " +
+			"locked, lock_reason, prunable, prunable_reason and sparse_profile " +
+			"for scripts: 'json' for a JSON array, 'tsv' for tab-separated values with a header row, " +
+			"or a Go template applied to every worktree, e.g. --format '{{.Path}} {{.Branch}}'.",
 		ShellComplete: completeList,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -80,12 +88,25 @@ func NewListCommand() *cli.Command {
 				Aliases: []string{"q"},
 				Usage:   "Only display worktree paths",
 			},
+			&cli.BoolFlag{
+				Name:    "status",
+				Aliases: []string{"s"},
+				Usage:   "Show changes, ahead/behind counts and the last commit of each worktree",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Print worktrees for scripts: json, tsv or a Go template such as '{{.Path}} {{.Branch}}'",
+			},
 		},
 		Action: listCommand,
 	}
 }
 
 func listCommand(_ context.Context, cmd *cli.Command) error {
+	if cmd.String("format") != "" && (cmd.Bool("quiet") || cmd.Bool("status")) {
+		return fmt.Errorf("--format cannot be combined with --quiet or --status")
+	}
+
 	// Get current working directory (should be a git repository)
 	cwd, err := listGetwd()
 	if err != nil {
@@ -143,6 +164,12 @@ func listCommandWithCommandExecutor(
 
 	// Parse worktrees from command output
 	worktrees := parseWorktreesFromOutput(result.Results[0].Output)
+	markDefaultWorktree(executor, worktrees)
+	markSparseProfiles(worktrees)
+
+	if opts.Format != "" {
+		return displayWorktreesFormatted(w, worktrees, cwd, cfg, mainRepoPath, opts.Format)
+	}
 
 	if len(worktrees) == 0 {
 		if !quiet {
@@ -158,6 +185,10 @@ func listCommandWithCommandExecutor(
 		if err := displayWorktreesQuiet(w, worktrees, cfg, mainRepoPath); err != nil {
 			return err
 		}
+	} else if opts.Status {
+		if err := displayWorktreesWithStatus(w, worktrees, cwd, cfg, mainRepoPath, getTerminalWidth(), opts); err != nil {
+			return err
+		}
 	} else {
 		termWidth := getTerminalWidth()
 		if !opts.Compact {
@@ -184,16 +215,10 @@ func parseWorktreesFromOutput(output string) []git.Worktree {
 	lines := strings.Split(strings.TrimSpace(output), "\n")
 	var worktrees []git.Worktree
 	var currentWorktree git.Worktree
-	isFirst := true
 
 	for _, line := range lines {
 		if line == "" {
 			if currentWorktree.Path != "" {
-				// First worktree is always the main worktree
-				if isFirst {
-					currentWorktree.IsMain = true
-					isFirst = false
-				}
 				worktrees = append(worktrees, currentWorktree)
 				currentWorktree = git.Worktree{}
 			}
@@ -208,23 +233,34 @@ func parseWorktreesFromOutput(output string) []git.Worktree {
 			currentWorktree.Branch = strings.TrimPrefix(line, "branch refs/heads/")
 		} else if line == detachedKeyword {
 			currentWorktree.Branch = detachedKeyword
+			currentWorktree.Detached = true
+		} else {
+			currentWorktree.ParseStateLine(line)
 		}
 	}
 
 	if currentWorktree.Path != "" {
-		// First worktree is always the main worktree
-		if isFirst {
-			currentWorktree.IsMain = true
-		}
 		worktrees = append(worktrees, currentWorktree)
 	}
 
+	// The first worktree is the main one, unless the repository is bare
+	git.MarkMainWorktree(worktrees, "")
 	return worktrees
 }
 
-// isWorktreeManagedList determines if a worktree is managed by wtp (for list command)
-func isWorktreeManagedList(worktreePath string, cfg *config.Config, mainRepoPath string, isMain bool) bool {
-	return isWorktreeManagedCommon(worktreePath, cfg, mainRepoPath, isMain)
+// markSparseProfiles records the sparse-checkout profile of each worktree that has a working tree.
+func markSparseProfiles(worktrees []git.Worktree) {
+	for i := range worktrees {
+		if !worktrees[i].Bare && !worktrees[i].Prunable {
+			worktrees[i].SparseProfile = listSparseProfile(worktrees[i].Path)
+		}
+	}
+}
+
+// isWorktreeManagedList determines if a worktree is managed by wtp (for list command).
+// The repository entry of a bare layout never is.
+func isWorktreeManagedList(wt git.Worktree, cfg *config.Config, mainRepoPath string) bool {
+	return !wt.Bare && isWorktreeManagedCommon(wt.Path, cfg, mainRepoPath, wt.IsMain)
 }
 
 // formatBranchDisplay formats branch name for display, following Git conventions
@@ -238,6 +274,28 @@ func formatBranchDisplay(branch string) string {
 	return branch
 }
 
+// formatWorktreeState formats the STATUS column: whether wtp manages the worktree,
+// followed by the bare, locked and prunable states git reports and the sparse profile.
+func formatWorktreeState(wt git.Worktree, managed bool) string {
+	states := []string{"unmanaged"}
+	if managed {
+		states[0] = "managed"
+	}
+	if wt.Bare {
+		states = append(states, "bare")
+	}
+	if wt.Locked {
+		states = append(states, "locked")
+	}
+	if wt.Prunable {
+		states = append(states, "prunable")
+	}
+	if wt.SparseProfile != "" {
+		states = append(states, "sparse: "+wt.SparseProfile)
+	}
+	return strings.Join(states, ", ")
+}
+
 // truncatePath truncates a path to fit within the given width, showing beginning and end
 func truncatePath(path string, maxWidth int) string {
 	if len(path) <= maxWidth {
@@ -366,10 +424,7 @@ func collectListDisplayData(
 
 		branchDisplay := formatBranchDisplay(wt.Branch)
 
-		statusDisplay := "unmanaged"
-		if isWorktreeManagedList(wt.Path, cfg, mainRepoPath, wt.IsMain) {
-			statusDisplay = "managed"
-		}
+		statusDisplay := formatWorktreeState(wt, isWorktreeManagedList(wt, cfg, mainRepoPath))
 
 		if len(pathDisplay) > metrics.maxPathLen {
 			metrics.maxPathLen = len(pathDisplay)
@@ -470,6 +525,8 @@ type listDisplayOptions struct {
 	Compact      bool
 	MaxPathWidth int
 	OutputIsTTY  bool
+	Status       bool
+	Format       string
 }
 
 func resolveListDisplayOptions(cmd *cli.Command, w io.Writer) listDisplayOptions {
@@ -496,5 +553,7 @@ func resolveListDisplayOptions(cmd *cli.Command, w io.Writer) listDisplayOptions
 		Compact:      compact,
 		MaxPathWidth: maxPathWidth,
 		OutputIsTTY:  outputIsTTY,
+		Status:       cmd.Bool("status"),
+		Format:       cmd.String("format"),
 	}
 }
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+// Built-in values of 'wtp list --format'; any other value is a Go template.
+const (
+	listFormatJSON = "json"
+	listFormatTSV  = "tsv"
+)
+
+// listEntry is the machine-readable form of a worktree printed by 'wtp list --format'.
+// Its fields are a stable interface for scripts; add new ones rather than renaming.
+type listEntry struct {
+	// Name is the worktree name shown in the PATH column and accepted by other commands.
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Branch   string `json:"branch"`
+	Head     string `json:"head"`
+	Detached bool   `json:"detached"`
+	IsMain   bool   `json:"is_main"`
+	Managed  bool   `json:"managed"`
+	Current  bool   `json:"current"`
+	Bare     bool   `json:"bare"`
+	// Locked worktrees are kept by 'git worktree prune' until 'wtp unlock'.
+	Locked         bool   `json:"locked"`
+	LockReason     string `json:"lock_reason"`
+	Prunable       bool   `json:"prunable"`
+	PrunableReason string `json:"prunable_reason"`
+	// SparseProfile is the profile of a worktree created with 'wtp add --sparse'.
+	SparseProfile string `json:"sparse_profile"`
+}
+
+var listTSVHeader = []string{
+	"name", "path", "branch", "head", "detached", "is_main", "managed", "current",
+	"bare", "locked", "lock_reason", "prunable", "prunable_reason",
+	"sparse_profile",
+}
+
+// tsvEscaper keeps every value on one line and in one column.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func newListEntries(worktrees []git.Worktree, currentPath string, cfg *config.Config, mainRepoPath string) []listEntry {
+	entries := make([]listEntry, 0, len(worktrees))
+	for _, wt := range worktrees {
+		entry := listEntry{
+			Name:           getWorktreeDisplayName(wt, cfg, mainRepoPath),
+			Path:           wt.Path,
+			Branch:         wt.Branch,
+			Head:           wt.HEAD,
+			Detached:       wt.Detached,
+			IsMain:         wt.IsMain,
+			Managed:        isWorktreeManagedList(wt, cfg, mainRepoPath),
+			Current:        wt.Path == currentPath,
+			Bare:           wt.Bare,
+			Locked:         wt.Locked,
+			LockReason:     wt.LockReason,
+			Prunable:       wt.Prunable,
+			PrunableReason: wt.PrunableReason,
+			SparseProfile:  wt.SparseProfile,
+		}
+		if wt.Detached {
+			entry.Branch = ""
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// displayWorktreesFormatted prints worktrees as a JSON array, as tab-separated
+// values with a header row, or through a Go template applied to every worktree.
+func displayWorktreesFormatted(
+	w io.Writer, worktrees []git.Worktree, currentPath string, cfg *config.Config, mainRepoPath, format string,
+) error {
+	entries := newListEntries(worktrees, currentPath, cfg, mainRepoPath)
+	switch format {
+	case listFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case listFormatTSV:
+		return writeListTSV(w, entries)
+	default:
+		return writeListTemplate(w, entries, format)
+	}
+}
+
+func writeListTSV(w io.Writer, entries []listEntry) error {
+	if _, err := fmt.Fprintln(w, strings.Join(listTSVHeader, "\t")); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		row := []string{
+			entry.Name, entry.Path, entry.Branch, entry.Head,
+			strconv.FormatBool(entry.Detached), strconv.FormatBool(entry.IsMain),
+			strconv.FormatBool(entry.Managed), strconv.FormatBool(entry.Current), strconv.FormatBool(entry.Bare),
+			strconv.FormatBool(entry.Locked), entry.LockReason, strconv.FormatBool(entry.Prunable), entry.PrunableReason,
+			entry.SparseProfile,
+		}
+		for i := range row {
+			row[i] = tsvEscaper.Replace(row[i])
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeListTemplate executes format once per worktree, ending each with a newline.
+func writeListTemplate(w io.Writer, entries []listEntry, format string) error {
+	tmpl, err := template.New("format").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid --format template: %w", err)
+	}
+	for _, entry := range entries {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, entry); err != nil {
+			return fmt.Errorf("invalid --format template: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
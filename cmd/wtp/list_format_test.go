@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+const formatTestWorktreeList = "worktree /test/repo\nHEAD abc123456789\nbranch refs/heads/main\n\n" +
+	"worktree /test/worktrees/feature/auth\nHEAD def456789012\nbranch refs/heads/feature/auth\n\n" +
+	"worktree /elsewhere/spike\nHEAD 999999999999\ndetached\nlocked on a usb drive\n\n"
+
+func runListWithFormat(t *testing.T, format string) (string, error) {
+	t.Helper()
+	originalGetwd, originalSparseProfile := listGetwd, listSparseProfile
+	t.Cleanup(func() { listGetwd, listSparseProfile = originalGetwd, originalSparseProfile })
+	listGetwd = func() (string, error) { return "/test/worktrees/feature/auth", nil }
+	listSparseProfile = func(path string) string {
+		if path == "/test/worktrees/feature/auth" {
+			return "web"
+		}
+		return ""
+	}
+
+	mockExec := &mockListCommandExecutor{results: []command.Result{{Output: formatTestWorktreeList}}}
+	cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+	opts := defaultListDisplayOptionsForTests()
+	opts.Format = format
+
+	var buf bytes.Buffer
+	err := listCommandWithCommandExecutor(&cli.Command{}, &buf, mockExec, cfg, "/test/repo", false, opts)
+	return buf.String(), err
+}
+
+func TestListCommand_FormatJSON(t *testing.T) {
+	output, err := runListWithFormat(t, "json")
+	require.NoError(t, err)
+
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(output), &entries))
+	assert.Equal(t, []map[string]any{
+		{
+			"name": "@", "path": "/test/repo", "branch": "main", "head": "abc123456789",
+			"detached": false, "is_main": true, "managed": true, "current": false,
+			"bare": false, "locked": false, "lock_reason": "", "prunable": false, "prunable_reason": "",
+			"sparse_profile": "",
+		},
+		{
+			"name": "feature/auth", "path": "/test/worktrees/feature/auth", "branch": "feature/auth",
+			"head": "def456789012", "detached": false, "is_main": false, "managed": true, "current": true,
+			"bare": false, "locked": false, "lock_reason": "", "prunable": false, "prunable_reason": "",
+			"sparse_profile": "web",
+		},
+		{
+			"name": "../../elsewhere/spike", "path": "/elsewhere/spike", "branch": "", "head": "999999999999",
+			"detached": true, "is_main": false, "managed": false, "current": false,
+			"bare": false, "locked": true, "lock_reason": "on a usb drive", "prunable": false, "prunable_reason": "",
+			"sparse_profile": "",
+		},
+	}, entries)
+}
+
+func TestListCommand_FormatJSON_NoWorktrees(t *testing.T) {
+	mockExec := &mockListCommandExecutor{results: []command.Result{{Output: ""}}}
+
+	var buf bytes.Buffer
+	opts := defaultListDisplayOptionsForTests()
+	opts.Format = "json"
+	err := listCommandWithCommandExecutor(&cli.Command{}, &buf, mockExec, &config.Config{}, "/test/repo", false, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestListCommand_FormatTSV(t *testing.T) {
+	output, err := runListWithFormat(t, "tsv")
+	require.NoError(t, err)
+
+	expected := "" +
+		"name\tpath\tbranch\thead\tdetached\tis_main\tmanaged\tcurrent\t" +
+		"bare\tlocked\tlock_reason\tprunable\tprunable_reason\tsparse_profile\n" +
+		"@\t/test/repo\tmain\tabc123456789\tfalse\ttrue\ttrue\tfalse\tfalse\tfalse\t\tfalse\t\t\n" +
+		"feature/auth\t/test/worktrees/feature/auth\tfeature/auth\tdef456789012\tfalse\tfalse\ttrue\ttrue\t" +
+		"false\tfalse\t\tfalse\t\tweb\n" +
+		"../../elsewhere/spike\t/elsewhere/spike\t\t999999999999\ttrue\tfalse\tfalse\tfalse\t" +
+		"false\ttrue\ton a usb drive\tfalse\t\t\n"
+	assert.Equal(t, expected, output)
+}
+
+func TestListCommand_FormatTemplate(t *testing.T) {
+	t.Run("executes the template for every worktree", func(t *testing.T) {
+		output, err := runListWithFormat(t, "{{.Path}} {{.Branch}}{{if .Current}} *{{end}}")
+		require.NoError(t, err)
+		assert.Equal(t, "/test/repo main\n/test/worktrees/feature/auth feature/auth *\n/elsewhere/spike \n", output)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := runListWithFormat(t, "{{.Nope}}")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --format template")
+	})
+
+	t.Run("rejects malformed templates", func(t *testing.T) {
+		_, err := runListWithFormat(t, "{{.Path")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --format template")
+	})
+}
+
+func TestListCommand_FormatConflicts(t *testing.T) {
+	for _, args := range [][]string{
+		{"list", "--format", "json", "--quiet"},
+		{"list", "--format", "json", "--status"},
+	} {
+		t.Run(args[len(args)-1], func(t *testing.T) {
+			app := &cli.Command{Name: "wtp", Commands: []*cli.Command{NewListCommand()}}
+			err := app.Run(context.Background(), append([]string{"wtp"}, args...))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "--format cannot be combined with --quiet or --status")
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+const (
+	// maxStatusWorkers bounds the git processes 'wtp list --status' runs at once.
+	maxStatusWorkers = 8
+	// minSubjectWidth keeps some of the commit subject visible on narrow terminals.
+	minSubjectWidth = 20
+	hoursPerDay     = 24
+	daysPerWeek     = 7
+	daysPerMonth    = 30
+	daysPerYear     = 365
+)
+
+// Variables to allow mocking in tests
+var (
+	listWorktreeStatus = git.WorktreeStatus
+	listDefaultBranch  = git.DefaultBranch
+	listNow            = time.Now
+)
+
+var statusColumnHeaders = []string{
+	"PATH", "BRANCH", "STATUS", "HEAD", "CHANGES", "UPSTREAM", "DEFAULT", "AGE", "SUBJECT",
+}
+
+type worktreeStatus struct {
+	status git.Status
+	err    error
+}
+
+// collectWorktreeStatuses gathers the status of every worktree concurrently, in worktree order.
+func collectWorktreeStatuses(worktrees []git.Worktree, baseRef string) []worktreeStatus {
+	results := make([]worktreeStatus, len(worktrees))
+	workers := make(chan struct{}, maxStatusWorkers)
+	var wg sync.WaitGroup
+	for i := range worktrees {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+			status, err := listWorktreeStatus(worktrees[i].Path, baseRef)
+			results[i] = worktreeStatus{status: status, err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// displayWorktreesWithStatus shows the worktree table with changes, ahead/behind
+// counts and the last commit of every worktree.
+func displayWorktreesWithStatus(
+	w io.Writer, worktrees []git.Worktree, currentPath string, cfg *config.Config, mainRepoPath string,
+	termWidth int, opts listDisplayOptions,
+) error {
+	items, _ := collectListDisplayData(worktrees, currentPath, cfg, mainRepoPath)
+	statuses := collectWorktreeStatuses(worktrees, listDefaultBranch(mainRepoPath))
+	now := listNow()
+
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = append([]string{item.path, item.branch, item.status, shortHead(item.head)},
+			statusCells(statuses[i], now)...)
+	}
+
+	widths := make([]int, len(statusColumnHeaders))
+	for i, header := range statusColumnHeaders {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	last := len(statusColumnHeaders) - 1
+	if opts.OutputIsTTY {
+		used := 0
+		for _, width := range widths[:last] {
+			used += width + 1
+		}
+		for _, row := range rows {
+			row[last] = truncateEnd(row[last], max(termWidth-used, minSubjectWidth))
+		}
+	}
+
+	dashes := make([]string, len(statusColumnHeaders))
+	for i, header := range statusColumnHeaders {
+		dashes[i] = strings.Repeat("-", len(header))
+	}
+	for _, row := range append([][]string{statusColumnHeaders, dashes}, rows...) {
+		if err := writeStatusRow(w, row, widths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeStatusRow(w io.Writer, row []string, widths []int) error {
+	var b strings.Builder
+	for i, cell := range row[:len(row)-1] {
+		fmt.Fprintf(&b, "%-*s ", widths[i], cell)
+	}
+	b.WriteString(row[len(row)-1])
+	_, err := fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+	return err
+}
+
+// statusCells returns the CHANGES, UPSTREAM, DEFAULT, AGE and SUBJECT cells of a worktree.
+func statusCells(result worktreeStatus, now time.Time) []string {
+	if result.err != nil {
+		return []string{"unavailable", "-", "-", "-", ""}
+	}
+	status := result.status
+	return []string{
+		formatChanges(status.Changed, status.Untracked),
+		formatAheadBehind(status.Upstream != "", status.Ahead, status.Behind),
+		formatAheadBehind(status.BaseRef != "", status.BaseAhead, status.BaseBehind),
+		formatCommitAge(status.LastCommitTime, now),
+		status.LastCommitSubject,
+	}
+}
+
+func shortHead(head string) string {
+	if len(head) > headDisplayLength {
+		return head[:headDisplayLength]
+	}
+	return head
+}
+
+// formatChanges shows changed paths as "~N" and untracked ones as "?N".
+func formatChanges(changed, untracked int) string {
+	if changed == 0 && untracked == 0 {
+		return "clean"
+	}
+	var parts []string
+	if changed > 0 {
+		parts = append(parts, fmt.Sprintf("~%d", changed))
+	}
+	if untracked > 0 {
+		parts = append(parts, fmt.Sprintf("?%d", untracked))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatAheadBehind shows commits ahead as "↑N" and behind as "↓N", "=" when even
+// and "-" when there is nothing to compare with.
+func formatAheadBehind(known bool, ahead, behind int) string {
+	if !known {
+		return "-"
+	}
+	if ahead == 0 && behind == 0 {
+		return "="
+	}
+	var parts []string
+	if ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", ahead))
+	}
+	if behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", behind))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatCommitAge shows how long ago a commit was made in its largest unit, e.g. "3d".
+func formatCommitAge(commitTime, now time.Time) string {
+	if commitTime.IsZero() {
+		return "-"
+	}
+	age := now.Sub(commitTime)
+	days := int(age.Hours()) / hoursPerDay
+	switch {
+	case age < time.Minute:
+		return "now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case days < 1:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	case days < daysPerWeek:
+		return fmt.Sprintf("%dd", days)
+	case days < daysPerMonth:
+		return fmt.Sprintf("%dw", days/daysPerWeek)
+	case days < daysPerYear:
+		return fmt.Sprintf("%dmo", days/daysPerMonth)
+	default:
+		return fmt.Sprintf("%dy", days/daysPerYear)
+	}
+}
+
+// truncateEnd shortens s to maxWidth runes, ending it with "...".
+func truncateEnd(s string, maxWidth int) string {
+	const ellipsis = "..."
+	if utf8.RuneCountInString(s) <= maxWidth {
+		return s
+	}
+	return string([]rune(s)[:maxWidth-len(ellipsis)]) + ellipsis
+}
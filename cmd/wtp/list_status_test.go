@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+func stubListStatus(t *testing.T, statuses map[string]git.Status) {
+	t.Helper()
+	originalStatus, originalDefault, originalNow := listWorktreeStatus, listDefaultBranch, listNow
+	t.Cleanup(func() {
+		listWorktreeStatus, listDefaultBranch, listNow = originalStatus, originalDefault, originalNow
+	})
+
+	listDefaultBranch = func(string) string { return "origin/main" }
+	listNow = func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) }
+	listWorktreeStatus = func(path, baseRef string) (git.Status, error) {
+		assert.Equal(t, "origin/main", baseRef)
+		status, ok := statuses[path]
+		if !ok {
+			return git.Status{}, errors.New("not a git repository")
+		}
+		return status, nil
+	}
+}
+
+func TestListCommand_Status(t *testing.T) {
+	stubListStatus(t, map[string]git.Status{
+		"/test/repo": {
+			BaseRef:           "origin/main",
+			Upstream:          "origin/main",
+			LastCommitTime:    time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+			LastCommitSubject: "Release 2.0",
+		},
+		"/test/worktrees/feature/auth": {
+			Changed:           2,
+			Untracked:         1,
+			Upstream:          "origin/feature/auth",
+			Ahead:             3,
+			BaseRef:           "origin/main",
+			BaseAhead:         5,
+			BaseBehind:        1,
+			LastCommitTime:    time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+			LastCommitSubject: "Add login form with a subject long enough to be truncated",
+		},
+	})
+	originalGetwd := listGetwd
+	t.Cleanup(func() { listGetwd = originalGetwd })
+	listGetwd = func() (string, error) { return "/test/repo", nil }
+	originalWidth := getTerminalWidth
+	t.Cleanup(func() { getTerminalWidth = originalWidth })
+	getTerminalWidth = func() int { return 100 }
+
+	mockExec := &mockListCommandExecutor{results: []command.Result{{Output: "worktree /test/repo\nHEAD abc123456789\n" +
+		"branch refs/heads/main\n\nworktree /test/worktrees/feature/auth\nHEAD def456789012\n" +
+		"branch refs/heads/feature/auth\n\nworktree /test/worktrees/gone\nHEAD 999999999999\n" +
+		"branch refs/heads/gone\n\n"}}}
+	cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+	opts := defaultListDisplayOptionsForTests()
+	opts.Status = true
+
+	var buf bytes.Buffer
+	err := listCommandWithCommandExecutor(&cli.Command{}, &buf, mockExec, cfg, "/test/repo", false, opts)
+	require.NoError(t, err)
+
+	expected := "" +
+		"PATH         BRANCH       STATUS  HEAD     CHANGES     UPSTREAM DEFAULT AGE SUBJECT\n" +
+		"----         ------       ------  ----     -------     -------- ------- --- -------\n" +
+		"@*           main         managed abc12345 clean       =        =       3d  Release 2.0\n" +
+		"feature/auth feature/auth managed def45678 ~2 ?1       ↑3       ↑5 ↓1   2h  Add login form with a...\n" +
+		"gone         gone         managed 99999999 unavailable -        -       -\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestCollectWorktreeStatuses_RunsConcurrently(t *testing.T) {
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	originalStatus := listWorktreeStatus
+	t.Cleanup(func() { listWorktreeStatus = originalStatus })
+	listWorktreeStatus = func(path, _ string) (git.Status, error) {
+		current := running.Add(1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+		return git.Status{LastCommitSubject: path}, nil
+	}
+
+	worktrees := make([]git.Worktree, maxStatusWorkers*2)
+	for i := range worktrees {
+		worktrees[i].Path = string(rune('a' + i))
+	}
+	go func() {
+		for peak.Load() < maxStatusWorkers {
+			time.Sleep(time.Millisecond)
+		}
+		close(release)
+	}()
+
+	results := collectWorktreeStatuses(worktrees, "")
+	assert.Equal(t, int32(maxStatusWorkers), peak.Load())
+	for i, result := range results {
+		assert.Equal(t, worktrees[i].Path, result.status.LastCommitSubject, "results keep worktree order")
+	}
+}
+
+func TestFormatCommitAge(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := map[time.Duration]string{
+		30 * time.Second:     "now",
+		45 * time.Minute:     "45m",
+		5 * time.Hour:        "5h",
+		3 * 24 * time.Hour:   "3d",
+		15 * 24 * time.Hour:  "2w",
+		90 * 24 * time.Hour:  "3mo",
+		800 * 24 * time.Hour: "2y",
+	}
+	for age, expected := range tests {
+		assert.Equal(t, expected, formatCommitAge(now.Add(-age), now), age.String())
+	}
+	assert.Equal(t, "-", formatCommitAge(time.Time{}, now))
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
 
 	"github.com/satococoa/wtp/v2/internal/command"
@@ -524,6 +525,93 @@ branch refs/heads/feature/test
 	assert.Contains(t, output, "(detached HEAD)")
 }
 
+func TestListCommand_LockedAndPrunableStates(t *testing.T) {
+	oldGetwd := listGetwd
+	listGetwd = func() (string, error) {
+		return "/test/repo", nil
+	}
+	defer func() {
+		listGetwd = oldGetwd
+	}()
+
+	mockOutput := `worktree /test/repo
+HEAD abc123
+branch refs/heads/main
+
+worktree /test/worktrees/usb
+HEAD def456
+branch refs/heads/usb
+locked on a usb drive
+
+worktree /test/worktrees/gone
+HEAD ghi789
+branch refs/heads/gone
+prunable gitdir file points to non-existent location
+
+`
+
+	mockExec := &mockListCommandExecutor{results: []command.Result{{Output: mockOutput}}}
+	cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+
+	var buf bytes.Buffer
+	err := listCommandWithCommandExecutor(
+		&cli.Command{}, &buf, mockExec, cfg, "/test/repo", false, defaultListDisplayOptionsForTests(),
+	)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Regexp(t, `^usb\s+usb\s+managed, locked\s+def456$`, lines[3])
+	assert.Regexp(t, `^gone\s+gone\s+managed, prunable\s+ghi789$`, lines[4])
+}
+
+func TestListCommand_SparseProfiles(t *testing.T) {
+	oldGetwd, oldSparseProfile := listGetwd, listSparseProfile
+	listGetwd = func() (string, error) {
+		return "/test/repo", nil
+	}
+	listSparseProfile = func(path string) string {
+		if path == "/test/repo" {
+			return ""
+		}
+		return "web"
+	}
+	defer func() {
+		listGetwd, listSparseProfile = oldGetwd, oldSparseProfile
+	}()
+
+	mockOutput := `worktree /test/repo
+HEAD abc123
+branch refs/heads/main
+
+worktree /test/worktrees/frontend
+HEAD def456
+branch refs/heads/frontend
+locked
+
+worktree /test/worktrees/gone
+HEAD ghi789
+branch refs/heads/gone
+prunable gitdir file points to non-existent location
+
+`
+
+	mockExec := &mockListCommandExecutor{results: []command.Result{{Output: mockOutput}}}
+	cfg := &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+
+	var buf bytes.Buffer
+	err := listCommandWithCommandExecutor(
+		&cli.Command{}, &buf, mockExec, cfg, "/test/repo", false, defaultListDisplayOptionsForTests(),
+	)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Regexp(t, `^@\*\s+main\s+managed\s+abc123$`, lines[2])
+	assert.Regexp(t, `^frontend\s+frontend\s+managed, locked, sparse: web\s+def456$`, lines[3])
+	assert.Regexp(t, `^gone\s+gone\s+managed, prunable\s+ghi789$`, lines[4])
+}
+
 func TestListCommand_HeaderFormatting(t *testing.T) {
 	mockExec := &mockListCommandExecutor{
 		results: []command.Result{
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+// NewLockCommand creates the lock command definition
+func NewLockCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "lock",
+		Usage:     "Lock a worktree so that it is not pruned",
+		UsageText: "wtp lock <worktree> [--reason <text>]",
+		Description: "Locks a worktree so that 'git worktree prune' keeps it while its directory is missing, " +
+			"e.g. on a removable drive, and so that 'wtp remove' refuses to remove it.\n\n" +
+			"Examples:\n" +
+			"  wtp lock feature/usb                          # Lock worktree\n" +
+			"  wtp lock feature/usb --reason 'on usb drive'  # Record why it is locked",
+		ShellComplete: completeWorktrees,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "reason",
+				Usage: "Explain why the worktree is locked (shown by 'wtp list --format')",
+			},
+		},
+		Action: lockCommand,
+	}
+}
+
+// NewUnlockCommand creates the unlock command definition
+func NewUnlockCommand() *cli.Command {
+	return &cli.Command{
+		Name:          "unlock",
+		Usage:         "Unlock a locked worktree",
+		UsageText:     "wtp unlock <worktree>",
+		Description:   "Unlocks a worktree locked with 'wtp lock', so that it can be pruned and removed again.",
+		ShellComplete: completeWorktrees,
+		Action:        unlockCommand,
+	}
+}
+
+func lockCommand(_ context.Context, cmd *cli.Command) error {
+	w, err := lockCommandWriter(cmd)
+	if err != nil {
+		return err
+	}
+	return lockCommandWithCommandExecutor(w, command.NewRealExecutor(), cmd.Args().First(), cmd.String("reason"))
+}
+
+func unlockCommand(_ context.Context, cmd *cli.Command) error {
+	w, err := lockCommandWriter(cmd)
+	if err != nil {
+		return err
+	}
+	return unlockCommandWithCommandExecutor(w, command.NewRealExecutor(), cmd.Args().First())
+}
+
+// lockCommandWriter checks that wtp runs in a git repository and returns the output writer.
+func lockCommandWriter(cmd *cli.Command) (io.Writer, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.DirectoryAccessFailed("access current", ".", err)
+	}
+
+	if _, err := git.NewRepository(cwd); err != nil {
+		return nil, errors.NotInGitRepository()
+	}
+
+	w := cmd.Root().Writer
+	if w == nil {
+		w = os.Stdout
+	}
+	return w, nil
+}
+
+func lockCommandWithCommandExecutor(w io.Writer, executor command.Executor, worktreeName, reason string) error {
+	path, err := resolveWorktreeToLock(executor, worktreeName, "wtp lock <worktree> [--reason <text>]")
+	if err != nil {
+		return err
+	}
+
+	if err := runGitWorktreeCommand(executor, command.GitWorktreeLock(path, reason)); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Locked worktree '%s' at %s", worktreeName, path)
+	if reason != "" {
+		message += fmt.Sprintf(" (%s)", reason)
+	}
+	_, err = fmt.Fprintln(w, message)
+	return err
+}
+
+func unlockCommandWithCommandExecutor(w io.Writer, executor command.Executor, worktreeName string) error {
+	path, err := resolveWorktreeToLock(executor, worktreeName, "wtp unlock <worktree>")
+	if err != nil {
+		return err
+	}
+
+	if err := runGitWorktreeCommand(executor, command.GitWorktreeUnlock(path)); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "Unlocked worktree '%s' at %s\n", worktreeName, path)
+	return err
+}
+
+func resolveWorktreeToLock(executor command.Executor, worktreeName, usage string) (string, error) {
+	worktreeName = strings.TrimSpace(worktreeName)
+	if worktreeName == "" {
+		return "", fmt.Errorf("worktree name is required\n\nUsage: %s", usage)
+	}
+
+	worktrees, err := listWorktreesWithExecutor(executor)
+	if err != nil {
+		return "", err
+	}
+
+	mainWorktreePath := findMainWorktreePath(worktrees)
+	path := resolveWorktreePathByName(worktreeName, worktrees, mainWorktreePath)
+	if path == "" {
+		return "", errors.WorktreeNotFound(worktreeName, availableManagedWorktreeNames(worktrees, mainWorktreePath))
+	}
+	return path, nil
+}
+
+// runGitWorktreeCommand runs a git worktree subcommand, reporting git's own
+// message, e.g. when the worktree is already locked.
+func runGitWorktreeCommand(executor command.Executor, cmd command.Command) error {
+	_, err := runGitCommandOutput(executor, cmd)
+	return err
+}
+
+// runGitCommandOutput runs a git command and returns its trimmed output, or an
+// error carrying git's own message.
+func runGitCommandOutput(executor command.Executor, cmd command.Command) (string, error) {
+	name := "git " + strings.Join(cmd.Args[:min(2, len(cmd.Args))], " ")
+	result, err := executor.Execute([]command.Command{cmd})
+	if err != nil {
+		return "", errors.GitCommandFailed(name, err.Error())
+	}
+	if len(result.Results) == 0 {
+		return "", nil
+	}
+	output := strings.TrimSpace(result.Results[0].Output)
+	if result.Results[0].Error != nil {
+		msg := result.Results[0].Error.Error()
+		if output != "" {
+			msg = output
+		}
+		return "", errors.GitCommandFailed(name, msg)
+	}
+	return output, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+)
+
+const lockTestWorktreeList = `worktree /repo/main
+HEAD abc
+branch refs/heads/main
+
+worktree /repo/worktrees/feature/usb
+HEAD def
+branch refs/heads/feature/usb
+`
+
+func TestNewLockCommands(t *testing.T) {
+	lock := NewLockCommand()
+	assert.Equal(t, "lock", lock.Name)
+	assert.NotNil(t, lock.Action)
+	assert.NotNil(t, lock.ShellComplete)
+
+	unlock := NewUnlockCommand()
+	assert.Equal(t, "unlock", unlock.Name)
+	assert.NotNil(t, unlock.Action)
+	assert.NotNil(t, unlock.ShellComplete)
+}
+
+func TestLockCommandWithCommandExecutor(t *testing.T) {
+	t.Run("locks the resolved worktree with a reason", func(t *testing.T) {
+		mock := &mockExecCommandExecutor{
+			results: []*command.ExecutionResult{
+				{Results: []command.Result{{Output: lockTestWorktreeList}}},
+				{Results: []command.Result{{}}},
+			},
+		}
+
+		var buf bytes.Buffer
+		err := lockCommandWithCommandExecutor(&buf, mock, "feature/usb", "on a usb drive")
+		require.NoError(t, err)
+		require.Len(t, mock.executed, 2)
+		assert.Equal(t, command.GitWorktreeLock("/repo/worktrees/feature/usb", "on a usb drive"), mock.executed[1][0])
+		assert.Equal(t,
+			"Locked worktree 'feature/usb' at /repo/worktrees/feature/usb (on a usb drive)\n", buf.String())
+	})
+
+	t.Run("reports git errors", func(t *testing.T) {
+		mock := &mockExecCommandExecutor{
+			results: []*command.ExecutionResult{
+				{Results: []command.Result{{Output: lockTestWorktreeList}}},
+				{Results: []command.Result{{
+					Output: "fatal: '/repo/worktrees/feature/usb' is already locked",
+					Error:  assert.AnError,
+				}}},
+			},
+		}
+
+		err := lockCommandWithCommandExecutor(&bytes.Buffer{}, mock, "feature/usb", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "git worktree lock")
+		assert.Contains(t, err.Error(), "is already locked")
+	})
+
+	t.Run("unknown worktree", func(t *testing.T) {
+		mock := &mockExecCommandExecutor{
+			results: []*command.ExecutionResult{
+				{Results: []command.Result{{Output: lockTestWorktreeList}}},
+			},
+		}
+
+		err := lockCommandWithCommandExecutor(&bytes.Buffer{}, mock, "missing", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "worktree 'missing' not found")
+		assert.Len(t, mock.executed, 1)
+	})
+
+	t.Run("requires a worktree name", func(t *testing.T) {
+		err := lockCommandWithCommandExecutor(&bytes.Buffer{}, &mockExecCommandExecutor{}, " ", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "worktree name is required")
+	})
+}
+
+func TestUnlockCommandWithCommandExecutor(t *testing.T) {
+	mock := &mockExecCommandExecutor{
+		results: []*command.ExecutionResult{
+			{Results: []command.Result{{Output: lockTestWorktreeList}}},
+			{Results: []command.Result{{}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := unlockCommandWithCommandExecutor(&buf, mock, "feature/usb")
+	require.NoError(t, err)
+	require.Len(t, mock.executed, 2)
+	assert.Equal(t, command.GitWorktreeUnlock("/repo/worktrees/feature/usb"), mock.executed[1][0])
+	assert.Equal(t, "Unlocked worktree 'feature/usb' at /repo/worktrees/feature/usb\n", buf.String())
+}
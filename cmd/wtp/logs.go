@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/git"
+	"github.com/satococoa/wtp/v2/internal/hooks"
+)
+
+// logFollowInterval is how often 'wtp logs --follow' polls the log for new output.
+const logFollowInterval = 500 * time.Millisecond
+
+// NewLogsCommand creates the logs command definition
+func NewLogsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "logs",
+		Usage:     "Show recorded post-create hook output for a worktree",
+		UsageText: "wtp logs [<worktree>] [--hook <number>] [--follow]",
+		Description: "Every hook run by 'wtp add' or 'wtp hooks run' is recorded with a timestamp, hook number, " +
+			"exit code and duration in the worktree's git directory. Without an argument the current worktree " +
+			"is used.\n\n" +
+			"Examples:\n" +
+			"  wtp logs feature/auth                   # Show every recorded hook run\n" +
+			"  wtp logs feature/auth --hook 2          # Show only runs of the second hook\n" +
+			"  wtp logs feature/auth --follow          # Stream new output while hooks run",
+		ArgsUsage:     "[worktree-name]",
+		ShellComplete: completeWorktrees,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "hook",
+				Usage: "Show only output of the given hook number",
+			},
+			&cli.BoolFlag{
+				Name:    "follow",
+				Aliases: []string{"f"},
+				Usage:   "Keep printing output as it is appended",
+			},
+		},
+		Action: logsCommand,
+	}
+}
+
+func logsCommand(ctx context.Context, cmd *cli.Command) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return errors.DirectoryAccessFailed("access current", ".", err)
+	}
+
+	if _, err := git.NewRepository(cwd); err != nil {
+		return errors.NotInGitRepository()
+	}
+
+	w := cmd.Root().Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	executor := command.NewRealExecutor()
+	return logsCommandWithCommandExecutor(ctx, cmd, w, executor, cwd)
+}
+
+func logsCommandWithCommandExecutor(
+	ctx context.Context,
+	cmd *cli.Command,
+	w io.Writer,
+	executor command.Executor,
+	cwd string,
+) error {
+	hookNumber := cmd.Int("hook")
+	if hookNumber < 0 {
+		return fmt.Errorf("invalid hook number %d: hooks are numbered from 1", hookNumber)
+	}
+
+	worktreeName, targetPath, err := resolveLogTarget(executor, cmd.Args().First(), cwd)
+	if err != nil {
+		return err
+	}
+
+	gitDir, err := git.WorktreeGitDir(targetPath)
+	if err != nil {
+		return fmt.Errorf("failed to locate git directory of worktree '%s': %w", worktreeName, err)
+	}
+
+	logPath := hooks.LogPath(gitDir)
+	// #nosec G304 -- logPath is derived from the worktree's git directory
+	logFile, err := os.Open(logPath)
+	if os.IsNotExist(err) && !cmd.Bool("follow") {
+		_, writeErr := fmt.Fprintf(w, "No hook output recorded for worktree '%s'\n", worktreeName)
+		return writeErr
+	}
+	if os.IsNotExist(err) {
+		logFile, err = waitForLog(ctx, logPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open hook log: %w", err)
+	}
+	if logFile == nil {
+		return nil
+	}
+	defer func() { _ = logFile.Close() }()
+
+	filter := hooks.NewLogFilter(w, hookNumber)
+	if _, err := io.Copy(filter, logFile); err != nil {
+		return err
+	}
+	if !cmd.Bool("follow") {
+		return nil
+	}
+
+	return followLog(ctx, logFile, filter)
+}
+
+// resolveLogTarget resolves the worktree whose log is shown, defaulting to the one containing cwd.
+func resolveLogTarget(executor command.Executor, worktreeName, cwd string) (name, path string, err error) {
+	worktrees, err := listWorktreesWithExecutor(executor)
+	if err != nil {
+		return "", "", err
+	}
+
+	if worktreeName == "" {
+		current := findWorktreeContaining(worktrees, cwd)
+		if current == nil {
+			return "", "", fmt.Errorf("current directory is not inside a worktree; specify a worktree name")
+		}
+		return current.Name(), current.Path, nil
+	}
+
+	mainWorktreePath := findMainWorktreePath(worktrees)
+	path = resolveWorktreePathByName(worktreeName, worktrees, mainWorktreePath)
+	if path == "" {
+		return "", "", errors.WorktreeNotFound(worktreeName, availableManagedWorktreeNames(worktrees, mainWorktreePath))
+	}
+	return worktreeName, path, nil
+}
+
+// waitForLog polls until the log at path exists. It returns a nil file when ctx is cancelled first.
+func waitForLog(ctx context.Context, path string) (*os.File, error) {
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-ticker.C:
+		}
+
+		// #nosec G304 -- path is derived from the worktree's git directory
+		file, err := os.Open(path)
+		if !os.IsNotExist(err) {
+			return file, err
+		}
+	}
+}
+
+// followLog copies output appended to the log until ctx is cancelled.
+func followLog(ctx context.Context, logFile *os.File, w io.Writer) error {
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if _, err := io.Copy(w, logFile); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/hooks"
+)
+
+// ===== Command Structure Tests =====
+
+func TestNewLogsCommand(t *testing.T) {
+	cmd := NewLogsCommand()
+	assert.Equal(t, "logs", cmd.Name)
+	assert.NotNil(t, cmd.Action)
+	assert.NotNil(t, cmd.ShellComplete)
+
+	flagNames := make(map[string]bool)
+	for _, flag := range cmd.Flags {
+		flagNames[flag.Names()[0]] = true
+	}
+	assert.True(t, flagNames["hook"])
+	assert.True(t, flagNames["follow"])
+}
+
+// ===== Command Execution Tests =====
+
+func TestLogsCommand_HookOutput(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		runHooks         bool
+		expectedOutput   []string
+		unexpectedOutput []string
+	}{
+		{
+			name:     "shows output recorded by hook execution",
+			args:     []string{"feature/auth"},
+			runHooks: true,
+			expectedOutput: []string{
+				"hook 1 of 2 (copy)",
+				"Copying: .env → .env",
+				"<== hook 2 exit=1",
+				"source path does not exist",
+			},
+		},
+		{
+			name:             "--hook shows a single hook",
+			args:             []string{"--hook", "2", "feature/auth"},
+			runHooks:         true,
+			expectedOutput:   []string{"hook 2 of 2 (copy)"},
+			unexpectedOutput: []string{"Copying"},
+		},
+		{
+			name:           "reports when nothing was recorded",
+			args:           []string{"feature/auth"},
+			expectedOutput: []string{"No hook output recorded for worktree 'feature/auth'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, _ := setupLogsTestWorktree(t)
+			mainRepoPath := filepath.Join(root, "repo")
+			worktreeList := fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+				"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n\n", root)
+
+			if tt.runHooks {
+				require.NoError(t, os.WriteFile(filepath.Join(mainRepoPath, ".env"), []byte("A=1"), 0o600))
+				cfg := &config.Config{
+					Hooks: config.Hooks{
+						PostCreate: []config.Hook{
+							{Type: config.HookTypeCopy, From: ".env", To: ".env"},
+							{Type: config.HookTypeCopy, From: ".missing", To: ".missing"},
+						},
+					},
+				}
+				var hookOutput bytes.Buffer
+				require.Error(t, executePostCreateHooks(&hookOutput, cfg, mainRepoPath,
+					filepath.Join(root, "worktrees", "feature", "auth")))
+			}
+
+			var out syncBuffer
+			err := runLogsCommand(context.Background(), t, &out, worktreeList, mainRepoPath, tt.args)
+
+			require.NoError(t, err)
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, out.String(), expected)
+			}
+			for _, unexpected := range tt.unexpectedOutput {
+				assert.NotContains(t, out.String(), unexpected)
+			}
+		})
+	}
+}
+
+func TestLogsCommand_Follow(t *testing.T) {
+	root, logPath := setupLogsTestWorktree(t)
+	worktreeList := fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+		"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n\n", root)
+	require.NoError(t, os.MkdirAll(filepath.Dir(logPath), 0o750))
+	require.NoError(t, os.WriteFile(logPath, []byte("before\n"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() {
+		done <- runLogsCommand(ctx, t, &out, worktreeList, filepath.Join(root, "repo"),
+			[]string{"--follow", "feature/auth"})
+	}()
+
+	logFile, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = logFile.WriteString("after\n")
+	require.NoError(t, err)
+	require.NoError(t, logFile.Close())
+
+	assert.Eventually(t, func() bool {
+		return out.String() == "before\nafter\n"
+	}, 5*time.Second, 50*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// ===== Error Handling Tests =====
+
+func TestLogsCommand_WorktreeNotFound(t *testing.T) {
+	root, _ := setupLogsTestWorktree(t)
+	worktreeList := fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+		"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n\n", root)
+
+	var out syncBuffer
+	err := runLogsCommand(context.Background(), t, &out, worktreeList, filepath.Join(root, "repo"),
+		[]string{"missing"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "worktree 'missing' not found")
+}
+
+// ===== Helper Functions =====
+
+// setupLogsTestWorktree creates a main worktree at <root>/repo and the worktree
+// feature/auth under <root>/worktrees, and returns root and the hook log path.
+func setupLogsTestWorktree(t *testing.T) (root, logPath string) {
+	t.Helper()
+	root = t.TempDir()
+	adminDir := filepath.Join(root, "repo", ".git", "worktrees", "auth")
+	worktreePath := filepath.Join(root, "worktrees", "feature", "auth")
+	require.NoError(t, os.MkdirAll(adminDir, 0o750))
+	require.NoError(t, os.MkdirAll(worktreePath, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, ".git"), []byte("gitdir: "+adminDir+"\n"), 0o600))
+	return root, hooks.LogPath(adminDir)
+}
+
+func runLogsCommand(
+	ctx context.Context, t *testing.T, w *syncBuffer, worktreeList, mainRepoPath string, args []string,
+) error {
+	t.Helper()
+
+	var runErr error
+	logsCmd := NewLogsCommand()
+	logsCmd.Action = func(ctx context.Context, cmd *cli.Command) error {
+		executor := &mockListCommandExecutor{results: []command.Result{{Output: worktreeList}}}
+		runErr = logsCommandWithCommandExecutor(ctx, cmd, w, executor, mainRepoPath)
+		return nil
+	}
+
+	app := &cli.Command{Name: "wtp", Commands: []*cli.Command{logsCmd}}
+	require.NoError(t, app.Run(ctx, append([]string{"wtp", "logs"}, args...)))
+	return runErr
+}
+
+// ===== Mock Implementations =====
+
+// syncBuffer is a bytes.Buffer that can be written by 'logs --follow' while the test reads it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/git"
+	"github.com/satococoa/wtp/v2/internal/hooks"
+)
+
+const moveUsage = "wtp move <worktree> <new-name> [--with-branch]"
+
+// NewMoveCommand creates the move command definition
+func NewMoveCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "move",
+		Aliases:   []string{"mv"},
+		Usage:     "Move a worktree to a new name",
+		UsageText: moveUsage,
+		Description: "Moves a managed worktree to the path its new name resolves to under base_dir, " +
+			"the same path 'wtp add <new-name>' would use. Links created by symlink hooks that point " +
+			"into the old location, or relative links whose depth changed, are fixed up. Refuses when " +
+			"something already exists at the new path, or with --with-branch when the new branch name is " +
+			"taken.\n\n" +
+			"Examples:\n" +
+			"  wtp move feature/auth feature/login                # Move worktree\n" +
+			"  wtp mv feature/auth feature/login --with-branch    # Also rename its branch",
+		ShellComplete: completeWorktrees,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "with-branch",
+				Usage: "Also rename the branch of the worktree to the new name",
+			},
+		},
+		Action: moveCommand,
+	}
+}
+
+func moveCommand(_ context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return errors.DirectoryAccessFailed("access current", ".", err)
+	}
+
+	_, cfg, mainRepoPath, err := setupRepoAndConfig()
+	if err != nil {
+		return err
+	}
+
+	return moveCommandWithCommandExecutor(w, command.NewRealExecutor(), cfg, mainRepoPath, cwd,
+		cmd.Args().Get(0), cmd.Args().Get(1), cmd.Bool("with-branch"))
+}
+
+func moveCommandWithCommandExecutor(
+	w io.Writer, executor command.Executor, cfg *config.Config, mainRepoPath, cwd string,
+	worktreeName, newName string, withBranch bool,
+) error {
+	worktreeName = strings.TrimSpace(worktreeName)
+	newName = strings.TrimSpace(newName)
+	if worktreeName == "" || newName == "" {
+		return fmt.Errorf("worktree name and new name are required\n\nUsage: %s", moveUsage)
+	}
+
+	worktrees, err := listWorktreesWithExecutor(executor)
+	if err != nil {
+		return err
+	}
+	target, err := findTargetWorktreeFromList(worktrees, worktreeName)
+	if err != nil {
+		return err
+	}
+	if isPathWithin(target.Path, cwd) {
+		return errors.CannotMoveCurrentWorktree(worktreeName, target.Path)
+	}
+	renameBranch := withBranch && target.Branch != newName
+	if err := checkBranchRename(executor, *target, worktreeName, newName, renameBranch); err != nil {
+		return err
+	}
+
+	baseDir := cfg.ResolveWorktreePath(mainRepoPath, "")
+	newPath, err := prepareMoveDestination(worktreeName, newName, baseDir, cfg.ResolveWorktreePath(mainRepoPath, newName))
+	if err != nil {
+		return err
+	}
+	// Relative symlink targets were computed from the resolved worktree path.
+	oldPath := target.Path
+	if resolved, err := filepath.EvalSymlinks(oldPath); err == nil {
+		oldPath = resolved
+	}
+
+	if err := runGitWorktreeCommand(executor, command.GitWorktreeMove(target.Path, newPath)); err != nil {
+		removeEmptyParents(newPath, baseDir)
+		return err
+	}
+	removeEmptyParents(target.Path, baseDir)
+	if _, err := fmt.Fprintf(w, "Moved worktree '%s' to '%s' at %s\n", worktreeName, newName, newPath); err != nil {
+		return err
+	}
+
+	if renameBranch {
+		if err := renameBranchWithCommandExecutor(w, executor, target.Branch, newName); err != nil {
+			return err
+		}
+	}
+
+	if resolved, err := filepath.EvalSymlinks(newPath); err == nil {
+		newPath = resolved
+	}
+	return hooks.NewExecutor(cfg, mainRepoPath).RelinkSymlinks(w, oldPath, newPath)
+}
+
+// prepareMoveDestination checks that newPath is a free path inside baseDir and
+// creates its parent directories, which 'git worktree move' does not.
+func prepareMoveDestination(worktreeName, newName, baseDir, newPath string) (string, error) {
+	if newPath == baseDir || !isPathWithin(baseDir, newPath) {
+		return "", fmt.Errorf("new name '%s' must resolve to a path inside %s", newName, baseDir)
+	}
+	if _, err := os.Lstat(newPath); err == nil {
+		return "", fmt.Errorf("cannot move worktree '%s': %s already exists", worktreeName, newPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0o750); err != nil {
+		return "", errors.DirectoryAccessFailed("create", filepath.Dir(newPath), err)
+	}
+	return newPath, nil
+}
+
+// checkBranchRename makes sure the branch of target can take newName before the
+// worktree moves, so that a failing rename does not leave the move half done.
+func checkBranchRename(
+	executor command.Executor, target git.Worktree, worktreeName, newName string, rename bool,
+) error {
+	if !rename {
+		return nil
+	}
+	if target.Branch == "" || target.Detached {
+		return fmt.Errorf("worktree '%s' has no branch to rename; move it without --with-branch", worktreeName)
+	}
+	result, err := executor.Execute([]command.Command{command.GitRevParseVerify("refs/heads/" + newName)})
+	if err != nil {
+		return err
+	}
+	if len(result.Results) > 0 && result.Results[0].Error == nil && strings.TrimSpace(result.Results[0].Output) != "" {
+		return fmt.Errorf("cannot rename branch '%s' to '%s': a branch named '%s' already exists",
+			target.Branch, newName, newName)
+	}
+	return nil
+}
+
+func renameBranchWithCommandExecutor(w io.Writer, executor command.Executor, oldName, newName string) error {
+	if err := runGitWorktreeCommand(executor, command.GitBranchRename(oldName, newName)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Renamed branch '%s' to '%s'\n", oldName, newName)
+	return err
+}
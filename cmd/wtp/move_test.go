@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+// ===== Command Structure Tests =====
+
+func TestNewMoveCommand(t *testing.T) {
+	cmd := NewMoveCommand()
+	assert.Equal(t, "move", cmd.Name)
+	assert.Equal(t, []string{"mv"}, cmd.Aliases)
+	assert.NotNil(t, cmd.Action)
+	assert.NotNil(t, cmd.ShellComplete)
+}
+
+// ===== Command Execution Tests =====
+
+func TestMoveCommand_CommandConstruction(t *testing.T) {
+	tests := []struct {
+		name             string
+		newName          string
+		withBranch       bool
+		expectedCommands func(root string) []command.Command
+		expectedOutput   func(root string) string
+		expectedDirs     []string
+	}{
+		{
+			name:       "moves the worktree and renames its branch",
+			newName:    "login",
+			withBranch: true,
+			expectedCommands: func(root string) []command.Command {
+				return []command.Command{
+					command.GitWorktreeList(),
+					command.GitRevParseVerify("refs/heads/login"),
+					command.GitWorktreeMove(root+"/worktrees/feature/auth", root+"/worktrees/login"),
+					command.GitBranchRename("feature/auth", "login"),
+				}
+			},
+			expectedOutput: func(root string) string {
+				return "Moved worktree 'feature/auth' to 'login' at " + root + "/worktrees/login\n" +
+					"Renamed branch 'feature/auth' to 'login'\n"
+			},
+		},
+		{
+			name:    "creates missing parent directories",
+			newName: "team/feature/login",
+			expectedCommands: func(root string) []command.Command {
+				return []command.Command{
+					command.GitWorktreeList(),
+					command.GitWorktreeMove(root+"/worktrees/feature/auth", root+"/worktrees/team/feature/login"),
+				}
+			},
+			expectedDirs: []string{"worktrees/team/feature"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupMoveTestRepo(t)
+			mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{{Results: []command.Result{{
+				Output: fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+					"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n", root),
+			}}}}}
+
+			var buf bytes.Buffer
+			mainRepoPath := filepath.Join(root, "repo")
+			err := moveCommandWithCommandExecutor(&buf, mock, createMoveTestConfig(), mainRepoPath, mainRepoPath,
+				"feature/auth", tt.newName, tt.withBranch)
+
+			require.NoError(t, err)
+			var executed []command.Command
+			for _, commands := range mock.executed {
+				executed = append(executed, commands...)
+			}
+			assert.Equal(t, tt.expectedCommands(root), executed)
+			if tt.expectedOutput != nil {
+				assert.Equal(t, tt.expectedOutput(root), buf.String())
+			}
+			for _, dir := range tt.expectedDirs {
+				assert.DirExists(t, filepath.Join(root, dir))
+			}
+		})
+	}
+}
+
+// ===== Error Handling Tests =====
+
+func TestMoveCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name             string
+		newName          string
+		withBranch       bool
+		cwd              string
+		existingDir      string
+		gitResults       []command.Result
+		expectedError    string
+		expectedCommands int
+		missingDirs      []string
+	}{
+		{
+			name:             "refuses to rename the branch to an existing branch before moving",
+			newName:          "login",
+			withBranch:       true,
+			gitResults:       []command.Result{{Output: "abc123\n"}},
+			expectedError:    "a branch named 'login' already exists",
+			expectedCommands: 2,
+		},
+		{
+			name:             "refuses an existing destination",
+			newName:          "login",
+			existingDir:      "worktrees/login",
+			expectedError:    "already exists",
+			expectedCommands: 1,
+		},
+		{
+			name:             "refuses names outside base_dir",
+			newName:          "../escape",
+			expectedError:    "must resolve to a path inside",
+			expectedCommands: 1,
+		},
+		{
+			name:             "refuses the current worktree",
+			newName:          "login",
+			cwd:              "worktrees/feature/auth/src",
+			expectedError:    "cannot move worktree 'feature/auth'",
+			expectedCommands: 1,
+		},
+		{
+			name:    "reports git errors and cleans up created directories",
+			newName: "team/login",
+			gitResults: []command.Result{{
+				Output: "fatal: cannot move a locked working tree",
+				Error:  assert.AnError,
+			}},
+			expectedError:    "cannot move a locked working tree",
+			expectedCommands: 2,
+			missingDirs:      []string{"worktrees/team"},
+		},
+		{
+			name:             "requires both names",
+			newName:          " ",
+			expectedError:    "Usage: wtp move",
+			expectedCommands: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupMoveTestRepo(t)
+			if tt.existingDir != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(root, tt.existingDir), 0o750))
+			}
+			cwd := filepath.Join(root, "repo")
+			if tt.cwd != "" {
+				cwd = filepath.Join(root, tt.cwd)
+			}
+			mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{{Results: []command.Result{{
+				Output: fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+					"worktree %[1]s/worktrees/feature/auth\nHEAD def\nbranch refs/heads/feature/auth\n", root),
+			}}}}}
+			for _, result := range tt.gitResults {
+				mock.results = append(mock.results, &command.ExecutionResult{Results: []command.Result{result}})
+			}
+
+			err := moveCommandWithCommandExecutor(&bytes.Buffer{}, mock, createMoveTestConfig(),
+				filepath.Join(root, "repo"), cwd, "feature/auth", tt.newName, tt.withBranch)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			assert.Len(t, mock.executed, tt.expectedCommands)
+			assert.DirExists(t, filepath.Join(root, "worktrees", "feature", "auth"))
+			for _, dir := range tt.missingDirs {
+				assert.NoDirExists(t, filepath.Join(root, dir))
+			}
+		})
+	}
+}
+
+// ===== Helper Functions =====
+
+// setupMoveTestRepo creates the main worktree at <root>/repo and the worktree
+// feature/auth under <root>/worktrees, so that destination checks see the
+// filesystem, and returns root.
+func setupMoveTestRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "repo"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "worktrees", "feature", "auth"), 0o750))
+	return root
+}
+
+func createMoveTestConfig() *config.Config {
+	return &config.Config{Defaults: config.Defaults{BaseDir: "../worktrees"}}
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/git"
+)
+
+// Variables to allow mocking in tests
+var (
+	pruneOrphanedDirs   = git.OrphanedWorktreeDirs
+	pruneMergedBranches = git.MergedBranches
+	pruneDefaultBranch  = git.DefaultBranch
+	pruneRemoveAll      = os.RemoveAll
+	pruneLeftovers      = git.LeftoverBranches
+	pruneForgetBranches = git.ForgetLeftoverBranches
+)
+
+// NewPruneCommand creates the prune command definition
+func NewPruneCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "prune",
+		Usage:     "Clean up stale worktrees, orphaned directories and leftover branches",
+		UsageText: "wtp prune [--dry-run] [--yes]",
+		Description: "Finds worktrees whose directories are gone, directories under base_dir that were worktrees " +
+			"of this repository but are no longer registered with git, and the branches these worktrees, or " +
+			"worktrees removed earlier with 'wtp remove', left behind once they are merged into the default " +
+			"branch and no longer checked out. Shows what it found and, once confirmed, runs " +
+			"'git worktree prune', deletes the directories and deletes the branches with 'git branch -d'.\n\n" +
+			"Locked worktrees are kept, and so are other branches such as develop or release branches. " +
+			"Branches that 'git branch -d' refuses to delete, e.g. because they are merged into the default " +
+			"branch but not into HEAD, are reported and kept.\n\n" +
+			"Examples:\n" +
+			"  wtp prune                               # Show the plan and ask before pruning\n" +
+			"  wtp prune --dry-run                     # Only show the plan\n" +
+			"  wtp prune --yes                         # Prune without asking",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"n"},
+				Usage:   "Show what would be pruned without changing anything",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Prune without asking for confirmation",
+			},
+		},
+		Action: pruneCommand,
+	}
+}
+
+type pruneOptions struct {
+	DryRun bool
+	Yes    bool
+}
+
+// prunePlan lists everything 'wtp prune' would clean up.
+type prunePlan struct {
+	baseRef  string
+	stale    []git.Worktree
+	orphans  []string
+	branches []string
+}
+
+func (p prunePlan) empty() bool {
+	return len(p.stale) == 0 && len(p.orphans) == 0 && len(p.branches) == 0
+}
+
+func pruneCommand(_ context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	_, cfg, mainRepoPath, err := setupRepoAndConfig()
+	if err != nil {
+		return err
+	}
+
+	opts := pruneOptions{DryRun: cmd.Bool("dry-run"), Yes: cmd.Bool("yes")}
+	return pruneCommandWithCommandExecutor(w, os.Stdin, command.NewRealExecutor(), cfg, mainRepoPath, opts)
+}
+
+func pruneCommandWithCommandExecutor(
+	w io.Writer, in io.Reader, executor command.Executor, cfg *config.Config, mainRepoPath string, opts pruneOptions,
+) error {
+	worktrees, err := listWorktreesWithExecutor(executor)
+	if err != nil {
+		return err
+	}
+
+	plan, err := buildPrunePlan(worktrees, cfg, mainRepoPath)
+	if err != nil {
+		return err
+	}
+	if plan.empty() {
+		_, err := fmt.Fprintln(w, "Nothing to prune")
+		return err
+	}
+
+	if err := writePrunePlan(w, plan, cfg, mainRepoPath); err != nil {
+		return err
+	}
+	if opts.DryRun {
+		return nil
+	}
+
+	if !opts.Yes {
+		confirmed, err := confirmAction(w, in, "Prune these?",
+			"run 'wtp prune --yes' to prune or 'wtp prune --dry-run' to only show the plan")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			_, err := fmt.Fprintln(w, "Nothing was pruned")
+			return err
+		}
+	}
+
+	return runPrunePlan(w, executor, plan, mainRepoPath, cfg.ResolveWorktreePath(mainRepoPath, ""))
+}
+
+// buildPrunePlan collects stale worktrees, orphaned directories under base_dir and
+// the merged branches worktrees left behind that are not checked out once the stale
+// worktrees are pruned.
+func buildPrunePlan(worktrees []git.Worktree, cfg *config.Config, mainRepoPath string) (prunePlan, error) {
+	var plan prunePlan
+	checkedOut := make(map[string]bool)
+	for _, wt := range worktrees {
+		if isStaleWorktree(wt) {
+			plan.stale = append(plan.stale, wt)
+		} else if wt.Branch != "" && !wt.Detached {
+			checkedOut[wt.Branch] = true
+		}
+	}
+
+	baseDir := cfg.ResolveWorktreePath(mainRepoPath, "")
+	orphans, err := pruneOrphanedDirs(mainRepoPath, baseDir)
+	if err != nil {
+		return plan, err
+	}
+	plan.orphans = orphans
+
+	plan.baseRef = pruneDefaultBranch(mainRepoPath)
+	if plan.baseRef == "" {
+		return plan, nil
+	}
+	candidates, err := leftoverBranchCandidates(plan, mainRepoPath, baseDir)
+	if err != nil {
+		return plan, err
+	}
+	merged, err := pruneMergedBranches(mainRepoPath, plan.baseRef)
+	if err != nil {
+		return plan, err
+	}
+	defaultBranch := strings.TrimPrefix(plan.baseRef, "origin/")
+	for _, branch := range merged {
+		if candidates[branch] && !checkedOut[branch] && branch != defaultBranch {
+			plan.branches = append(plan.branches, branch)
+		}
+	}
+	return plan, nil
+}
+
+// leftoverBranchCandidates returns the branches 'wtp prune' may delete: those of the
+// stale worktrees, those whose worktree path under base_dir is an orphaned directory,
+// and those 'wtp remove' recorded when it kept the branch of a removed worktree.
+func leftoverBranchCandidates(plan prunePlan, mainRepoPath, baseDir string) (map[string]bool, error) {
+	candidates := make(map[string]bool)
+	for _, wt := range plan.stale {
+		if wt.Branch != "" && !wt.Detached {
+			candidates[wt.Branch] = true
+		}
+	}
+
+	// Orphans are reported beneath the symlink-free base_dir.
+	if resolved, err := filepath.EvalSymlinks(baseDir); err == nil {
+		baseDir = resolved
+	}
+	for _, dir := range plan.orphans {
+		if rel, err := filepath.Rel(baseDir, dir); err == nil && isPathWithin(baseDir, dir) {
+			candidates[filepath.ToSlash(rel)] = true
+		}
+	}
+
+	recorded, err := pruneLeftovers(mainRepoPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range recorded {
+		candidates[branch] = true
+	}
+	return candidates, nil
+}
+
+// isStaleWorktree reports whether git can forget a worktree: git marks it prunable,
+// or, for git versions before 2.31, its directory is missing. Locked worktrees stay.
+func isStaleWorktree(wt git.Worktree) bool {
+	if wt.IsMain || wt.Bare || wt.Locked {
+		return false
+	}
+	if wt.Prunable {
+		return true
+	}
+	_, err := os.Stat(wt.Path)
+	return stdErrors.Is(err, fs.ErrNotExist)
+}
+
+func writePrunePlan(w io.Writer, plan prunePlan, cfg *config.Config, mainRepoPath string) error {
+	var b strings.Builder
+	if len(plan.stale) > 0 {
+		b.WriteString("Stale worktrees (their directories are gone):\n")
+		for _, wt := range plan.stale {
+			fmt.Fprintf(&b, "  %s (%s)\n", getWorktreeDisplayName(wt, cfg, mainRepoPath), wt.Path)
+		}
+	}
+	if len(plan.orphans) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("Orphaned directories (no longer registered with git):\n")
+		for _, dir := range plan.orphans {
+			fmt.Fprintf(&b, "  %s\n", dir)
+		}
+	}
+	if len(plan.branches) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "Branches merged into %s and no longer checked out:\n", plan.baseRef)
+		for _, branch := range plan.branches {
+			fmt.Fprintf(&b, "  %s\n", branch)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func runPrunePlan(w io.Writer, executor command.Executor, plan prunePlan, mainRepoPath, baseDir string) error {
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
+	if len(plan.stale) > 0 {
+		if err := runGitWorktreeCommand(executor, command.GitWorktreePrune()); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "Pruned %d stale worktree(s)\n", len(plan.stale)); err != nil {
+			return err
+		}
+	}
+
+	// Orphans are reported beneath the symlink-free base_dir.
+	if resolved, err := filepath.EvalSymlinks(baseDir); err == nil {
+		baseDir = resolved
+	}
+	for _, dir := range plan.orphans {
+		if err := pruneRemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove orphaned directory %s: %w", dir, err)
+		}
+		removeEmptyParents(dir, baseDir)
+		if _, err := fmt.Fprintf(w, "Removed orphaned directory %s\n", dir); err != nil {
+			return err
+		}
+	}
+
+	var deleted []string
+	for _, branch := range plan.branches {
+		ok, err := deletePrunedBranch(w, executor, branch)
+		if err != nil {
+			return err
+		}
+		if ok {
+			deleted = append(deleted, branch)
+		}
+	}
+	// Best effort: a stale record only makes prune look for a branch that is gone.
+	_ = pruneForgetBranches(mainRepoPath, deleted)
+	return nil
+}
+
+// deletePrunedBranch deletes a branch with 'git branch -d' and reports whether it
+// did. git refuses branches that are merged into the default branch but not into
+// HEAD; those are reported and kept rather than stopping the prune.
+func deletePrunedBranch(w io.Writer, executor command.Executor, branch string) (bool, error) {
+	result, err := executor.Execute([]command.Command{command.GitBranchDelete(branch, false)})
+	if err != nil {
+		return false, err
+	}
+	if len(result.Results) > 0 && result.Results[0].Error != nil {
+		reason := strings.TrimSpace(result.Results[0].Output)
+		if reason == "" {
+			reason = result.Results[0].Error.Error()
+		}
+		reason, _, _ = strings.Cut(strings.TrimPrefix(reason, "error: "), "\n")
+		_, err := fmt.Fprintf(w, "Kept branch '%s': %s\n", branch, reason)
+		return false, err
+	}
+	_, err = fmt.Fprintf(w, "Removed branch '%s'\n", branch)
+	return true, err
+}
+
+// removeEmptyParents removes the directories between dir and baseDir that are
+// left empty, such as "feature" after removing "feature/auth".
+func removeEmptyParents(dir, baseDir string) {
+	for parent := filepath.Dir(dir); parent != baseDir && isPathWithin(baseDir, parent); parent = filepath.Dir(parent) {
+		if os.Remove(parent) != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+// ===== Command Structure Tests =====
+
+func TestNewPruneCommand(t *testing.T) {
+	cmd := NewPruneCommand()
+	assert.Equal(t, "prune", cmd.Name)
+	assert.NotEmpty(t, cmd.Description)
+	assert.NotNil(t, cmd.Action)
+
+	flagNames := make(map[string]bool)
+	for _, flag := range cmd.Flags {
+		flagNames[flag.Names()[0]] = true
+	}
+	assert.True(t, flagNames["dry-run"])
+	assert.True(t, flagNames["yes"])
+}
+
+// ===== Pure Business Logic Tests =====
+
+func TestRemoveEmptyParents(t *testing.T) {
+	baseDir := t.TempDir()
+	dir := filepath.Join(baseDir, "a", "b", "c")
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	require.NoError(t, os.Remove(dir))
+
+	removeEmptyParents(dir, baseDir)
+	assert.NoDirExists(t, filepath.Join(baseDir, "a"))
+	assert.DirExists(t, baseDir)
+}
+
+// ===== Command Execution Tests =====
+
+func TestPruneCommand_DryRun(t *testing.T) {
+	root := setupPruneTestRepo(t)
+	forgotten := stubPruneDependencies(t, root, true,
+		[]string{"develop", "feature/done", "feature/live", "feature/old", "main"})
+	mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{{Results: []command.Result{{
+		Output: fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+			"worktree %[1]s/worktrees/feature/live\nHEAD def\nbranch refs/heads/feature/live\n\n"+
+			"worktree %[1]s/worktrees/feature/done\nHEAD ghi\nbranch refs/heads/feature/done\n"+
+			"prunable gitdir file points to non-existent location\n\n", root),
+	}}}}}
+
+	var buf bytes.Buffer
+	err := pruneCommandWithCommandExecutor(&buf, strings.NewReader(""), mock,
+		createPruneTestConfig(root), filepath.Join(root, "repo"), pruneOptions{DryRun: true})
+
+	require.NoError(t, err)
+	expected := "Stale worktrees (their directories are gone):\n" +
+		"  feature/done (" + root + "/worktrees/feature/done)\n" +
+		"\n" +
+		"Orphaned directories (no longer registered with git):\n" +
+		"  " + root + "/worktrees/feature/ghost\n" +
+		"\n" +
+		"Branches merged into origin/main and no longer checked out:\n" +
+		"  feature/done\n" +
+		"  feature/old\n"
+	assert.Equal(t, expected, buf.String())
+	assert.Len(t, mock.executed, 1, "only git worktree list runs")
+	assert.DirExists(t, filepath.Join(root, "worktrees", "feature", "ghost"))
+	assert.Empty(t, *forgotten)
+}
+
+func TestPruneCommand_Execution(t *testing.T) {
+	tests := []struct {
+		name              string
+		opts              pruneOptions
+		input             string
+		canPrompt         bool
+		mergedBranches    []string
+		gitResults        []command.Result
+		expectedCommands  []command.Command
+		expectedOutput    []string
+		unexpectedOutput  []string
+		expectedForgotten []string
+		orphanRemoved     bool
+	}{
+		{
+			name:           "prunes without asking with --yes",
+			opts:           pruneOptions{Yes: true},
+			mergedBranches: []string{"develop", "feature/done", "feature/live", "feature/old", "main"},
+			expectedCommands: []command.Command{
+				command.GitWorktreePrune(),
+				command.GitBranchDelete("feature/done", false),
+				command.GitBranchDelete("feature/old", false),
+			},
+			expectedOutput: []string{
+				"Pruned 1 stale worktree(s)\n",
+				"Removed orphaned directory ",
+				"Removed branch 'feature/done'\n",
+				"Removed branch 'feature/old'\n",
+			},
+			unexpectedOutput:  []string{"develop"},
+			expectedForgotten: []string{"feature/done", "feature/old"},
+			orphanRemoved:     true,
+		},
+		{
+			name:           "prunes when confirmed",
+			input:          "y\n",
+			canPrompt:      true,
+			mergedBranches: []string{"feature/done", "feature/old"},
+			expectedCommands: []command.Command{
+				command.GitWorktreePrune(),
+				command.GitBranchDelete("feature/done", false),
+				command.GitBranchDelete("feature/old", false),
+			},
+			expectedOutput:    []string{"Prune these? [y/N]: "},
+			expectedForgotten: []string{"feature/done", "feature/old"},
+			orphanRemoved:     true,
+		},
+		{
+			name:           "keeps everything when declined",
+			input:          "\n",
+			canPrompt:      true,
+			mergedBranches: []string{"feature/done", "feature/old"},
+			expectedOutput: []string{"Nothing was pruned\n"},
+		},
+		{
+			name:             "offers the branch of an orphaned directory",
+			opts:             pruneOptions{DryRun: true},
+			mergedBranches:   []string{"develop", "feature/ghost", "main"},
+			expectedOutput:   []string{"no longer checked out:\n  feature/ghost\n"},
+			unexpectedOutput: []string{"develop"},
+		},
+		{
+			name:           "reports branches git refuses to delete and keeps going",
+			opts:           pruneOptions{Yes: true},
+			mergedBranches: []string{"feature/done", "feature/old"},
+			gitResults: []command.Result{
+				{},
+				{
+					Output: "error: The branch 'feature/done' is not fully merged.\n" +
+						"If you are sure you want to delete it, run 'git branch -D feature/done'.",
+					Error: assert.AnError,
+				},
+			},
+			expectedCommands: []command.Command{
+				command.GitWorktreePrune(),
+				command.GitBranchDelete("feature/done", false),
+				command.GitBranchDelete("feature/old", false),
+			},
+			expectedOutput: []string{
+				"Kept branch 'feature/done': The branch 'feature/done' is not fully merged.\n",
+				"Removed branch 'feature/old'\n",
+			},
+			expectedForgotten: []string{"feature/old"},
+			orphanRemoved:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupPruneTestRepo(t)
+			forgotten := stubPruneDependencies(t, root, tt.canPrompt, tt.mergedBranches)
+			mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{{Results: []command.Result{{
+				Output: fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n"+
+					"worktree %[1]s/worktrees/feature/live\nHEAD def\nbranch refs/heads/feature/live\n\n"+
+					"worktree %[1]s/worktrees/feature/done\nHEAD ghi\nbranch refs/heads/feature/done\n"+
+					"prunable gitdir file points to non-existent location\n\n"+
+					"worktree /media/usb/feature/usb\nHEAD jkl\nbranch refs/heads/feature/usb\n"+
+					"locked on a usb drive\n", root),
+			}}}}}
+			for _, result := range tt.gitResults {
+				mock.results = append(mock.results, &command.ExecutionResult{Results: []command.Result{result}})
+			}
+
+			var buf bytes.Buffer
+			err := pruneCommandWithCommandExecutor(&buf, strings.NewReader(tt.input), mock,
+				createPruneTestConfig(root), filepath.Join(root, "repo"), tt.opts)
+
+			require.NoError(t, err)
+			var executed []command.Command
+			for _, commands := range mock.executed[1:] {
+				executed = append(executed, commands...)
+			}
+			assert.Equal(t, tt.expectedCommands, executed)
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, buf.String(), expected)
+			}
+			for _, unexpected := range tt.unexpectedOutput {
+				assert.NotContains(t, buf.String(), unexpected)
+			}
+			assert.Equal(t, tt.expectedForgotten, *forgotten)
+			if tt.orphanRemoved {
+				assert.NoDirExists(t, filepath.Join(root, "worktrees", "feature", "ghost"))
+				assert.DirExists(t, filepath.Join(root, "worktrees", "feature"), "parents that are not empty are kept")
+			} else {
+				assert.DirExists(t, filepath.Join(root, "worktrees", "feature", "ghost"))
+			}
+		})
+	}
+}
+
+// ===== Error Handling Tests =====
+
+func TestPruneCommand_RequiresYesWithoutTerminal(t *testing.T) {
+	root := setupPruneTestRepo(t)
+	stubPruneDependencies(t, root, false, []string{"feature/old"})
+	mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{{Results: []command.Result{{
+		Output: fmt.Sprintf("worktree %[1]s/repo\nHEAD abc\nbranch refs/heads/main\n\n", root),
+	}}}}}
+
+	var buf bytes.Buffer
+	err := pruneCommandWithCommandExecutor(&buf, strings.NewReader("y\n"), mock,
+		createPruneTestConfig(root), filepath.Join(root, "repo"), pruneOptions{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wtp prune --yes")
+	assert.Len(t, mock.executed, 1)
+	assert.DirExists(t, filepath.Join(root, "worktrees", "feature", "ghost"))
+}
+
+// ===== Edge Cases Tests =====
+
+func TestPruneCommand_NothingToPrune(t *testing.T) {
+	root := setupPruneTestRepo(t)
+	stubPruneDependencies(t, root, true, []string{"main"})
+	require.NoError(t, os.RemoveAll(filepath.Join(root, "worktrees", "feature", "ghost")))
+	mock := &mockExecCommandExecutor{results: []*command.ExecutionResult{{Results: []command.Result{{
+		Output: fmt.Sprintf("worktree %s/repo\nHEAD abc\nbranch refs/heads/main\n", root),
+	}}}}}
+
+	var buf bytes.Buffer
+	err := pruneCommandWithCommandExecutor(&buf, strings.NewReader(""), mock,
+		createPruneTestConfig(root), filepath.Join(root, "repo"), pruneOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Nothing to prune\n", buf.String())
+}
+
+// ===== Helper Functions =====
+
+// setupPruneTestRepo creates the live worktree feature/live and the orphaned
+// directory feature/ghost under <root>/worktrees, and returns root.
+func setupPruneTestRepo(t *testing.T) string {
+	t.Helper()
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	orphan := filepath.Join(root, "worktrees", "feature", "ghost")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "worktrees", "feature", "live"), 0o750))
+	require.NoError(t, os.MkdirAll(orphan, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(orphan, ".git"), []byte("gitdir: /gone\n"), 0o600))
+	return root
+}
+
+func createPruneTestConfig(root string) *config.Config {
+	return &config.Config{Defaults: config.Defaults{BaseDir: filepath.Join(root, "worktrees")}}
+}
+
+// stubPruneDependencies reports mergedBranches as merged into origin/main and
+// "feature/old" as left behind by 'wtp remove'. It returns the branches prune
+// forgets after deleting them.
+func stubPruneDependencies(t *testing.T, root string, canPrompt bool, mergedBranches []string) *[]string {
+	t.Helper()
+	originalOrphans, originalMerged := pruneOrphanedDirs, pruneMergedBranches
+	originalDefault, originalPrompt := pruneDefaultBranch, canPromptForConfirmation
+	originalLeftovers, originalForget := pruneLeftovers, pruneForgetBranches
+	t.Cleanup(func() {
+		pruneOrphanedDirs, pruneMergedBranches = originalOrphans, originalMerged
+		pruneDefaultBranch, canPromptForConfirmation = originalDefault, originalPrompt
+		pruneLeftovers, pruneForgetBranches = originalLeftovers, originalForget
+	})
+
+	var forgotten []string
+	pruneLeftovers = func(string) ([]string, error) { return []string{"feature/old"}, nil }
+	pruneForgetBranches = func(_ string, branches []string) error {
+		forgotten = append(forgotten, branches...)
+		return nil
+	}
+	pruneOrphanedDirs = func(repoPath, baseDir string) ([]string, error) {
+		assert.Equal(t, filepath.Join(root, "repo"), repoPath)
+		assert.Equal(t, filepath.Join(root, "worktrees"), baseDir)
+		orphan := filepath.Join(baseDir, "feature", "ghost")
+		if _, err := os.Stat(orphan); err != nil {
+			return nil, nil
+		}
+		return []string{orphan}, nil
+	}
+	pruneDefaultBranch = func(string) string { return "origin/main" }
+	pruneMergedBranches = func(_, base string) ([]string, error) {
+		assert.Equal(t, "origin/main", base)
+		return mergedBranches, nil
+	}
+	canPromptForConfirmation = func() bool { return canPrompt }
+	return &forgotten
+}
@@ -18,8 +18,11 @@ import (
 	"github.com/satococoa/wtp/v2/internal/git"
 )
 
-// Variable to allow mocking in tests
-var removeGetwd = os.Getwd
+// Variables to allow mocking in tests
+var (
+	removeGetwd                = os.Getwd
+	removeRecordLeftoverBranch = git.RecordLeftoverBranch
+)
 
 // isWorktreeManaged determines if a worktree is managed by wtp
 func isWorktreeManaged(worktreePath string, cfg *config.Config, mainRepoPath string, isMain bool) bool {
@@ -109,6 +112,7 @@ func removeCommandWithCommandExecutor(
 
 	// Parse worktrees from command output
 	worktrees := parseWorktreesFromOutput(result.Results[0].Output)
+	markDefaultWorktree(executor, worktrees)
 
 	// Find target worktree
 	targetWorktree, err := findTargetWorktreeFromList(worktrees, worktreeName)
@@ -153,11 +157,23 @@ func removeCommandWithCommandExecutor(
 		if err := removeBranchWithCommandExecutor(w, executor, targetWorktree.Branch, forceBranch); err != nil {
 			return err
 		}
+		return nil
 	}
 
+	recordLeftoverBranch(cwd, *targetWorktree)
 	return nil
 }
 
+// recordLeftoverBranch remembers the branch a removed worktree leaves behind, so that
+// 'wtp prune' can offer to delete it once it is merged. This is best effort: without
+// the record, prune only leaves the branch alone.
+func recordLeftoverBranch(repoPath string, wt git.Worktree) {
+	if wt.Branch == "" || wt.Detached {
+		return
+	}
+	_ = removeRecordLeftoverBranch(repoPath, wt.Branch)
+}
+
 func validateRemoveInput(worktreeName string, withBranch, forceBranch bool) error {
 	if worktreeName == "" {
 		return errors.WorktreeNameRequiredForRemove()
@@ -233,8 +249,8 @@ func findTargetWorktreeFromList(worktrees []git.Worktree, worktreeName string) (
 	}
 
 	for _, wt := range worktrees {
-		// Skip main worktree - it cannot be removed
-		if wt.IsMain {
+		// Skip main worktree and the repository of a bare layout - they cannot be removed
+		if wt.IsMain || wt.Bare {
 			continue
 		}
 
@@ -334,7 +350,7 @@ func getWorktreesForRemove(w io.Writer) error {
 	// Print worktrees for remove command (no main, no markers, managed only)
 	for i := range worktrees {
 		wt := &worktrees[i]
-		if !wt.IsMain && isWorktreeManaged(wt.Path, cfg, mainRepoPath, wt.IsMain) {
+		if !wt.IsMain && !wt.Bare && isWorktreeManaged(wt.Path, cfg, mainRepoPath, wt.IsMain) {
 			// Calculate worktree name as relative path from base_dir
 			name := getWorktreeNameFromPath(wt.Path, cfg, mainRepoPath, wt.IsMain)
 			if _, err := fmt.Fprintln(w, name); err != nil {
@@ -231,10 +231,11 @@ func TestRemoveCommand_CommandConstruction(t *testing.T) {
 
 func TestRemoveCommand_SuccessMessage(t *testing.T) {
 	tests := []struct {
-		name           string
-		worktreeName   string
-		branchFlag     bool
-		expectedOutput []string
+		name             string
+		worktreeName     string
+		branchFlag       bool
+		expectedOutput   []string
+		expectedLeftover []string
 	}{
 		{
 			name:         "remove worktree only",
@@ -244,6 +245,7 @@ func TestRemoveCommand_SuccessMessage(t *testing.T) {
 				"Removed worktree",
 				"feature-branch",
 			},
+			expectedLeftover: []string{"feature-branch"},
 		},
 		{
 			name:         "remove worktree and branch",
@@ -259,6 +261,15 @@ func TestRemoveCommand_SuccessMessage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			originalRecord := removeRecordLeftoverBranch
+			t.Cleanup(func() { removeRecordLeftoverBranch = originalRecord })
+			var leftovers []string
+			removeRecordLeftoverBranch = func(repoPath, branch string) error {
+				assert.Equal(t, "/test/repo", repoPath)
+				leftovers = append(leftovers, branch)
+				return nil
+			}
+
 			mockExec := &mockRemoveCommandExecutor{
 				results: []command.Result{
 					{
@@ -293,6 +304,7 @@ func TestRemoveCommand_SuccessMessage(t *testing.T) {
 			for _, expected := range tt.expectedOutput {
 				assert.Contains(t, output, expected)
 			}
+			assert.Equal(t, tt.expectedLeftover, leftovers)
 		})
 	}
 }
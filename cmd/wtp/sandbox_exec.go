@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/sandbox"
+)
+
+// NewSandboxExecCommand creates the hidden command that starts sandboxed command
+// hooks. wtp runs it itself; it is not meant to be called by users.
+func NewSandboxExecCommand() *cli.Command {
+	return &cli.Command{
+		Name:            sandbox.ExecCommand,
+		Usage:           "Run a program with writes restricted to the given paths (internal)",
+		Hidden:          true,
+		SkipFlagParsing: true,
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return sandbox.Exec(cmd.Args().Slice())
+		},
+	}
+}
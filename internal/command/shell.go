@@ -17,12 +17,17 @@ func NewRealShellExecutor() ShellExecutor {
 }
 
 // Execute runs the command using os/exec
-func (*realShellExecutor) Execute(name string, args []string, workDir string, interactive bool) (string, error) {
+func (*realShellExecutor) Execute(
+	name string, args []string, workDir string, env []string, interactive bool,
+) (string, error) {
 	cmd := exec.Command(name, args...)
 
 	if workDir != "" {
 		cmd.Dir = workDir
 	}
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	if interactive && hasTerminalIO() {
 		cmd.Stdin = os.Stdin
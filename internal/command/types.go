@@ -1,11 +1,30 @@
 package command
 
+import (
+	"strconv"
+	"strings"
+)
+
 // Command represents a shell command to be executed
 type Command struct {
 	Name        string // Command name (e.g., "git")
 	Args        []string
-	WorkDir     string // Optional working directory
-	Interactive bool   // Prefer direct stdio wiring for interactive commands
+	WorkDir     string   // Optional working directory
+	Interactive bool     // Prefer direct stdio wiring for interactive commands
+	Env         []string // Optional KEY=value entries added to the inherited environment
+}
+
+// String renders the command as a shell-like command line for display purposes.
+func (c Command) String() string {
+	parts := make([]string, 0, len(c.Args)+1)
+	parts = append(parts, c.Name)
+	for _, arg := range c.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
 }
 
 // Result represents the result of a single command execution
@@ -22,7 +41,7 @@ type ExecutionResult struct {
 
 // ShellExecutor interface abstracts the actual command execution
 type ShellExecutor interface {
-	Execute(name string, args []string, workDir string, interactive bool) (string, error)
+	Execute(name string, args []string, workDir string, env []string, interactive bool) (string, error)
 }
 
 // Executor interface defines how commands are executed
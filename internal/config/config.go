@@ -4,7 +4,12 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"go.yaml.in/yaml/v3"
 )
@@ -14,11 +19,22 @@ type Config struct {
 	Version  string   `yaml:"version"`
 	Defaults Defaults `yaml:"defaults,omitempty"`
 	Hooks    Hooks    `yaml:"hooks,omitempty"`
+	// SparseProfiles maps profile names to the directories, relative to the repository
+	// root, that 'wtp add --sparse <profile>' checks out in cone mode.
+	SparseProfiles map[string][]string `yaml:"sparse_profiles,omitempty"`
 }
 
 // Defaults represents default configuration values
 type Defaults struct {
 	BaseDir string `yaml:"base_dir,omitempty"`
+	// Submodules selects how 'wtp add' initializes submodules in new worktrees:
+	// SubmodulesNone (the default), SubmodulesInit or SubmodulesRecursive.
+	Submodules string `yaml:"submodules,omitempty"`
+	// PRRemote is the remote 'wtp add --pr' fetches from; DefaultPRRemote when empty.
+	PRRemote string `yaml:"pr_remote,omitempty"`
+	// PRRefspec is the ref of a pull request on PRRemote, with {n} standing for its
+	// number; DefaultPRRefspec when empty.
+	PRRefspec string `yaml:"pr_refspec,omitempty"`
 }
 
 // Hooks represents the post-create hooks configuration
@@ -28,12 +44,46 @@ type Hooks struct {
 
 // Hook represents a single hook configuration
 type Hook struct {
+	// ID optionally identifies the hook for --only-hook and --skip-hook; it must be unique.
+	ID string `yaml:"id,omitempty"`
+	// Name is an optional human-readable label, also accepted by hook selectors.
+	Name    string            `yaml:"name,omitempty"`
 	Type    string            `yaml:"type"` // "copy", "command", or "symlink"
 	From    string            `yaml:"from,omitempty"`
 	To      string            `yaml:"to,omitempty"`
 	Command string            `yaml:"command,omitempty"`
 	Env     map[string]string `yaml:"env,omitempty"`
 	WorkDir string            `yaml:"work_dir,omitempty"`
+	// Run is an inline, possibly multi-line, script; an alternative to Command (command hooks only).
+	Run string `yaml:"run,omitempty"`
+	// Script is a script file resolved from the main worktree (command hooks only).
+	Script string `yaml:"script,omitempty"`
+	// Shell is the interpreter used for Command, Run or Script, e.g. "bash" or "python3" (command hooks only).
+	Shell string `yaml:"shell,omitempty"`
+	// Mask lists environment variables whose values are redacted from hook output (command hooks only).
+	Mask []string `yaml:"mask,omitempty"`
+	// MaskFiles lists dotenv files, resolved from the main worktree, whose values are
+	// redacted from hook output (command hooks only).
+	MaskFiles []string `yaml:"mask_files,omitempty"`
+	// Interactive connects the hook to the terminal so that it can prompt (command hooks only).
+	Interactive bool `yaml:"interactive,omitempty"`
+	// EnvMode selects which variables of wtp's environment the hook inherits: EnvModeInherit
+	// (the default), EnvModeClean or EnvModeAllowlist (command hooks only).
+	EnvMode string `yaml:"env_mode,omitempty"`
+	// EnvAllow lists variable names or glob patterns such as "NODE_*" that an
+	// EnvModeAllowlist hook inherits (command hooks only).
+	EnvAllow []string `yaml:"env_allow,omitempty"`
+	// EnvFile is a dotenv file, resolved from the main worktree, whose variables are
+	// added to the hook's environment (command hooks only).
+	EnvFile string `yaml:"env_file,omitempty"`
+	// Sandbox restricts the hook's writes to the worktree, temporary directories and
+	// SandboxAllow, where the platform supports it (command hooks only).
+	Sandbox bool `yaml:"sandbox,omitempty"`
+	// SandboxAllow lists additional writable paths, absolute or relative to the home
+	// directory with "~/", such as package manager caches (command hooks only).
+	SandboxAllow []string `yaml:"sandbox_allow,omitempty"`
+	// Relative creates symlinks with a target relative to the link location (symlink hooks only).
+	Relative bool `yaml:"relative,omitempty"`
 }
 
 const (
@@ -50,6 +100,106 @@ const (
 	// HookTypeSymlink identifies a hook that creates symlinks.
 	HookTypeSymlink       = "symlink"
 	configFilePermissions = 0o600
+	// DefaultPRRemote is the remote 'wtp add --pr' fetches from by default.
+	DefaultPRRemote = "origin"
+	// DefaultPRRefspec is the GitHub pull request ref; GitLab merge requests use
+	// "refs/merge-requests/{n}/head".
+	DefaultPRRefspec = "refs/pull/{n}/head"
+	// PRNumberPlaceholder stands for the pull request number in PRRefspec.
+	PRNumberPlaceholder = "{n}"
+)
+
+// Submodule initialization modes for new worktrees.
+const (
+	// SubmodulesNone leaves submodules uninitialized, like 'git worktree add'.
+	SubmodulesNone = "none"
+	// SubmodulesInit initializes and checks out the submodules of the worktree.
+	SubmodulesInit = "init"
+	// SubmodulesRecursive also initializes submodules nested in submodules.
+	SubmodulesRecursive = "recursive"
+)
+
+// ValidateSubmodules checks a submodule initialization mode; empty means SubmodulesNone.
+func ValidateSubmodules(mode string) error {
+	switch mode {
+	case "", SubmodulesNone, SubmodulesInit, SubmodulesRecursive:
+		return nil
+	default:
+		return fmt.Errorf("invalid submodules mode '%s', must be '%s', '%s' or '%s'",
+			mode, SubmodulesNone, SubmodulesInit, SubmodulesRecursive)
+	}
+}
+
+// PullRequestRef returns the ref of pull request number on the remote, from
+// defaults.pr_refspec or DefaultPRRefspec.
+func (c *Config) PullRequestRef(number string) string {
+	refspec := c.Defaults.PRRefspec
+	if refspec == "" {
+		refspec = DefaultPRRefspec
+	}
+	return strings.ReplaceAll(refspec, PRNumberPlaceholder, number)
+}
+
+// validatePRRefspec checks a pull request ref template; empty means DefaultPRRefspec.
+func validatePRRefspec(refspec string) error {
+	if refspec == "" {
+		return nil
+	}
+	if !strings.HasPrefix(refspec, "refs/") || !strings.Contains(refspec, PRNumberPlaceholder) {
+		return fmt.Errorf("pr_refspec '%s' must be a ref starting with 'refs/' that contains %s, e.g. '%s'",
+			refspec, PRNumberPlaceholder, DefaultPRRefspec)
+	}
+	if strings.ContainsAny(refspec, ": *") {
+		return fmt.Errorf("pr_refspec '%s' must name a single ref without ':', '*' or spaces", refspec)
+	}
+	return nil
+}
+
+// SparseProfile returns the directories of the named sparse-checkout profile.
+func (c *Config) SparseProfile(name string) ([]string, error) {
+	directories, ok := c.SparseProfiles[name]
+	if !ok {
+		names := make([]string, 0, len(c.SparseProfiles))
+		for profile := range c.SparseProfiles {
+			names = append(names, profile)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("sparse profile '%s' is not defined; add it to sparse_profiles in %s",
+				name, ConfigFileName)
+		}
+		return nil, fmt.Errorf("sparse profile '%s' is not defined; available profiles: %s",
+			name, strings.Join(names, ", "))
+	}
+	return directories, nil
+}
+
+// validateSparseProfile checks that a profile lists directories inside the repository.
+func validateSparseProfile(name string, directories []string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("profile name must not be empty")
+	}
+	if len(directories) == 0 {
+		return fmt.Errorf("it must list at least one directory")
+	}
+	for _, dir := range directories {
+		cleaned := path.Clean(filepath.ToSlash(dir))
+		if strings.TrimSpace(dir) == "" || path.IsAbs(cleaned) || filepath.IsAbs(dir) ||
+			cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return fmt.Errorf("directory '%s' must be a subdirectory of the repository root", dir)
+		}
+	}
+	return nil
+}
+
+// Command hook environment modes.
+const (
+	// EnvModeInherit passes wtp's whole environment to the hook.
+	EnvModeInherit = "inherit"
+	// EnvModeClean passes only a minimal set of variables, such as PATH and HOME.
+	EnvModeClean = "clean"
+	// EnvModeAllowlist passes the minimal set plus the variables matching env_allow.
+	EnvModeAllowlist = "allowlist"
 )
 
 // LoadConfig loads configuration from .wtp.yml in the repository root
@@ -134,10 +284,31 @@ func (c *Config) ApplyDefaults() {
 
 // Validate validates the configuration without mutating it.
 func (c *Config) Validate() error {
+	if err := ValidateSubmodules(c.Defaults.Submodules); err != nil {
+		return fmt.Errorf("invalid defaults: %w", err)
+	}
+	if err := validatePRRefspec(c.Defaults.PRRefspec); err != nil {
+		return fmt.Errorf("invalid defaults: %w", err)
+	}
+	for name, directories := range c.SparseProfiles {
+		if err := validateSparseProfile(name, directories); err != nil {
+			return fmt.Errorf("invalid sparse profile '%s': %w", name, err)
+		}
+	}
+
+	ids := make(map[string]int, len(c.Hooks.PostCreate))
 	for i := range c.Hooks.PostCreate {
-		if err := c.Hooks.PostCreate[i].Validate(); err != nil {
+		hook := &c.Hooks.PostCreate[i]
+		if err := hook.Validate(); err != nil {
 			return fmt.Errorf("invalid hook %d: %w", i+1, err)
 		}
+		if hook.ID == "" {
+			continue
+		}
+		if previous, ok := ids[hook.ID]; ok {
+			return fmt.Errorf("invalid hook %d: id '%s' is already used by hook %d", i+1, hook.ID, previous+1)
+		}
+		ids[hook.ID] = i
 	}
 
 	return nil
@@ -158,8 +329,21 @@ func (h *Hook) ApplyDefaults() {
 	h.To = h.From
 }
 
+// hookIDPattern restricts hook ids to characters that are easy to pass on the
+// command line. Ids must not be plain numbers, which select hooks by position.
+var hookIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
 // Validate validates a single hook configuration without mutating it.
 func (h *Hook) Validate() error {
+	if h.ID != "" {
+		if !hookIDPattern.MatchString(h.ID) {
+			return fmt.Errorf("hook id '%s' may only contain letters, digits, '.', '_' and '-'", h.ID)
+		}
+		if _, err := strconv.Atoi(h.ID); err == nil {
+			return fmt.Errorf("hook id '%s' must not be a number; numbers select hooks by position", h.ID)
+		}
+	}
+
 	switch h.Type {
 	case HookTypeCopy:
 		if h.From == "" {
@@ -171,13 +355,12 @@ func (h *Hook) Validate() error {
 		if h.Command != "" {
 			return fmt.Errorf("copy hook should not have 'command' field")
 		}
-	case HookTypeCommand:
-		if h.Command == "" {
-			return fmt.Errorf("command hook requires 'command' field")
-		}
-		if h.From != "" || h.To != "" {
-			return fmt.Errorf("command hook should not have 'from' or 'to' fields")
+		if h.Relative {
+			return fmt.Errorf("copy hook should not have 'relative' field")
 		}
+		return h.validateNoCommandOptions()
+	case HookTypeCommand:
+		return h.validateCommand()
 	case HookTypeSymlink:
 		if h.From == "" || h.To == "" {
 			return fmt.Errorf("symlink hook requires both 'from' and 'to' fields")
@@ -185,10 +368,95 @@ func (h *Hook) Validate() error {
 		if h.Command != "" {
 			return fmt.Errorf("symlink hook should not have 'command' field")
 		}
+		return h.validateNoCommandOptions()
 	default:
 		return fmt.Errorf("invalid hook type '%s', must be 'copy', 'command', or 'symlink'", h.Type)
 	}
+}
+
+func (h *Hook) validateCommand() error {
+	sources := 0
+	for _, value := range []string{h.Command, h.Run, h.Script} {
+		if value != "" {
+			sources++
+		}
+	}
+	if sources == 0 {
+		return fmt.Errorf("command hook requires 'command' field (or 'run' or 'script')")
+	}
+	if sources > 1 {
+		return fmt.Errorf("command hook must set only one of 'command', 'run' or 'script'")
+	}
+	if h.From != "" || h.To != "" {
+		return fmt.Errorf("command hook should not have 'from' or 'to' fields")
+	}
+	if h.Relative {
+		return fmt.Errorf("command hook should not have 'relative' field")
+	}
+	for _, name := range h.Mask {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("command hook 'mask' entries must be environment variable names")
+		}
+	}
+	if h.Interactive && (len(h.Mask) > 0 || len(h.MaskFiles) > 0) {
+		return fmt.Errorf("interactive command hook cannot use 'mask' or 'mask_files': its output goes to the terminal")
+	}
+	if err := h.validateEnvMode(); err != nil {
+		return err
+	}
+	return h.validateSandbox()
+}
 
+func (h *Hook) validateSandbox() error {
+	if len(h.SandboxAllow) > 0 && !h.Sandbox {
+		return fmt.Errorf("command hook 'sandbox_allow' requires 'sandbox: true'")
+	}
+	for _, path := range h.SandboxAllow {
+		if !filepath.IsAbs(path) && path != "~" && !strings.HasPrefix(path, "~/") {
+			return fmt.Errorf("sandbox_allow path '%s' must be absolute or start with '~/'", path)
+		}
+	}
+	return nil
+}
+
+func (h *Hook) validateEnvMode() error {
+	switch h.EnvMode {
+	case "", EnvModeInherit, EnvModeClean:
+		if len(h.EnvAllow) > 0 {
+			return fmt.Errorf("command hook 'env_allow' requires 'env_mode: %s'", EnvModeAllowlist)
+		}
+	case EnvModeAllowlist:
+		if len(h.EnvAllow) == 0 {
+			return fmt.Errorf("command hook with 'env_mode: %s' requires 'env_allow' patterns", EnvModeAllowlist)
+		}
+	default:
+		return fmt.Errorf("invalid env_mode '%s', must be '%s', '%s' or '%s'",
+			h.EnvMode, EnvModeInherit, EnvModeClean, EnvModeAllowlist)
+	}
+	for _, pattern := range h.EnvAllow {
+		if _, err := path.Match(pattern, ""); err != nil || strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("invalid env_allow pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+func (h *Hook) validateNoCommandOptions() error {
+	if h.Run != "" || h.Script != "" || h.Shell != "" {
+		return fmt.Errorf("%s hook should not have 'run', 'script' or 'shell' fields", h.Type)
+	}
+	if len(h.Mask) > 0 || len(h.MaskFiles) > 0 {
+		return fmt.Errorf("%s hook should not have 'mask' or 'mask_files' fields", h.Type)
+	}
+	if h.Interactive {
+		return fmt.Errorf("%s hook should not have 'interactive' field", h.Type)
+	}
+	if h.EnvMode != "" || len(h.EnvAllow) > 0 || h.EnvFile != "" {
+		return fmt.Errorf("%s hook should not have 'env_mode', 'env_allow' or 'env_file' fields", h.Type)
+	}
+	if h.Sandbox || len(h.SandboxAllow) > 0 {
+		return fmt.Errorf("%s hook should not have 'sandbox' or 'sandbox_allow' fields", h.Type)
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -218,6 +219,78 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "recursive submodules",
+			config: &Config{
+				Version:  "1.0",
+				Defaults: Defaults{Submodules: SubmodulesRecursive},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid submodules mode",
+			config: &Config{
+				Version:  "1.0",
+				Defaults: Defaults{Submodules: "all"},
+			},
+			expectError: true,
+		},
+		{
+			name: "merge request refspec",
+			config: &Config{
+				Version:  "1.0",
+				Defaults: Defaults{PRRemote: "gitlab", PRRefspec: "refs/merge-requests/{n}/head"},
+			},
+			expectError: false,
+		},
+		{
+			name: "pr refspec without a number",
+			config: &Config{
+				Version:  "1.0",
+				Defaults: Defaults{PRRefspec: "refs/pull/head"},
+			},
+			expectError: true,
+		},
+		{
+			name: "pr refspec with a destination",
+			config: &Config{
+				Version:  "1.0",
+				Defaults: Defaults{PRRefspec: "refs/pull/{n}/head:refs/heads/pr/{n}"},
+			},
+			expectError: true,
+		},
+		{
+			name: "sparse profiles",
+			config: &Config{
+				Version:        "1.0",
+				SparseProfiles: map[string][]string{"web": {"apps/web", "libs/ui/"}},
+			},
+			expectError: false,
+		},
+		{
+			name: "sparse profile without directories",
+			config: &Config{
+				Version:        "1.0",
+				SparseProfiles: map[string][]string{"web": nil},
+			},
+			expectError: true,
+		},
+		{
+			name: "sparse profile outside the repository",
+			config: &Config{
+				Version:        "1.0",
+				SparseProfiles: map[string][]string{"web": {"apps/web", "../shared"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "sparse profile with the repository root",
+			config: &Config{
+				Version:        "1.0",
+				SparseProfiles: map[string][]string{"all": {"./"}},
+			},
+			expectError: true,
+		},
 		{
 			name: "invalid copy hook - missing from",
 			config: &Config{
@@ -233,6 +306,56 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "hooks with distinct ids",
+			config: &Config{
+				Version: "1.0",
+				Hooks: Hooks{
+					PostCreate: []Hook{
+						{ID: "env", Name: "Copy env", Type: HookTypeCopy, From: ".env"},
+						{ID: "npm-ci", Name: "Install dependencies", Type: HookTypeCommand, Command: "npm ci"},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "duplicate hook ids",
+			config: &Config{
+				Version: "1.0",
+				Hooks: Hooks{
+					PostCreate: []Hook{
+						{ID: "setup", Type: HookTypeCopy, From: ".env"},
+						{ID: "setup", Type: HookTypeCommand, Command: "npm ci"},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "numeric hook id",
+			config: &Config{
+				Version: "1.0",
+				Hooks: Hooks{
+					PostCreate: []Hook{
+						{ID: "2", Type: HookTypeCommand, Command: "npm ci"},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "hook id with spaces",
+			config: &Config{
+				Version: "1.0",
+				Hooks: Hooks{
+					PostCreate: []Hook{
+						{ID: "npm ci", Type: HookTypeCommand, Command: "npm ci"},
+					},
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "invalid command hook - missing command",
 			config: &Config{
@@ -306,6 +429,214 @@ func TestHookValidate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "valid relative symlink hook",
+			hook: Hook{
+				Type:     HookTypeSymlink,
+				From:     ".bin",
+				To:       ".bin",
+				Relative: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "copy hook with relative field",
+			hook: Hook{
+				Type:     HookTypeCopy,
+				From:     ".env.example",
+				To:       ".env",
+				Relative: true,
+			},
+			expectError: true,
+		},
+		{
+			name: "valid command hook with run and shell",
+			hook: Hook{
+				Type:  HookTypeCommand,
+				Run:   "set -o pipefail\nmake setup | tee setup.log\n",
+				Shell: "bash",
+			},
+			expectError: false,
+		},
+		{
+			name: "valid command hook with script",
+			hook: Hook{
+				Type:   HookTypeCommand,
+				Script: "scripts/setup.sh",
+			},
+			expectError: false,
+		},
+		{
+			name: "command hook with both command and run",
+			hook: Hook{
+				Type:    HookTypeCommand,
+				Command: "make",
+				Run:     "make",
+			},
+			expectError: true,
+		},
+		{
+			name: "copy hook with shell field",
+			hook: Hook{
+				Type:  HookTypeCopy,
+				From:  ".env.example",
+				Shell: "bash",
+			},
+			expectError: true,
+		},
+		{
+			name: "valid command hook with mask",
+			hook: Hook{
+				Type:      HookTypeCommand,
+				Command:   "make login",
+				Mask:      []string{"API_TOKEN"},
+				MaskFiles: []string{".env"},
+			},
+			expectError: false,
+		},
+		{
+			name: "symlink hook with mask field",
+			hook: Hook{
+				Type: HookTypeSymlink,
+				From: ".bin",
+				To:   ".bin",
+				Mask: []string{"API_TOKEN"},
+			},
+			expectError: true,
+		},
+		{
+			name: "valid interactive command hook",
+			hook: Hook{
+				Type:        HookTypeCommand,
+				Command:     "gh auth login",
+				Interactive: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "interactive command hook with mask",
+			hook: Hook{
+				Type:        HookTypeCommand,
+				Command:     "gh auth login",
+				Interactive: true,
+				Mask:        []string{"GH_TOKEN"},
+			},
+			expectError: true,
+		},
+		{
+			name: "valid clean command hook with env_file",
+			hook: Hook{
+				Type:    HookTypeCommand,
+				Command: "npm ci",
+				EnvMode: EnvModeClean,
+				EnvFile: ".env",
+			},
+			expectError: false,
+		},
+		{
+			name: "valid allowlist command hook",
+			hook: Hook{
+				Type:     HookTypeCommand,
+				Command:  "npm ci",
+				EnvMode:  EnvModeAllowlist,
+				EnvAllow: []string{"NODE_*", "CI"},
+			},
+			expectError: false,
+		},
+		{
+			name: "allowlist command hook without env_allow",
+			hook: Hook{
+				Type:    HookTypeCommand,
+				Command: "npm ci",
+				EnvMode: EnvModeAllowlist,
+			},
+			expectError: true,
+		},
+		{
+			name: "env_allow without allowlist mode",
+			hook: Hook{
+				Type:     HookTypeCommand,
+				Command:  "npm ci",
+				EnvAllow: []string{"NODE_*"},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid env_mode",
+			hook: Hook{
+				Type:    HookTypeCommand,
+				Command: "npm ci",
+				EnvMode: "sandbox",
+			},
+			expectError: true,
+		},
+		{
+			name: "malformed env_allow pattern",
+			hook: Hook{
+				Type:     HookTypeCommand,
+				Command:  "npm ci",
+				EnvMode:  EnvModeAllowlist,
+				EnvAllow: []string{"NODE_["},
+			},
+			expectError: true,
+		},
+		{
+			name: "symlink hook with env_file",
+			hook: Hook{
+				Type:    HookTypeSymlink,
+				From:    ".bin",
+				To:      ".bin",
+				EnvFile: ".env",
+			},
+			expectError: true,
+		},
+		{
+			name: "valid sandboxed command hook",
+			hook: Hook{
+				Type:         HookTypeCommand,
+				Command:      "npm ci",
+				Sandbox:      true,
+				SandboxAllow: []string{"~/.npm", "/opt/cache"},
+			},
+			expectError: false,
+		},
+		{
+			name: "sandbox_allow without sandbox",
+			hook: Hook{
+				Type:         HookTypeCommand,
+				Command:      "npm ci",
+				SandboxAllow: []string{"~/.npm"},
+			},
+			expectError: true,
+		},
+		{
+			name: "relative sandbox_allow path",
+			hook: Hook{
+				Type:         HookTypeCommand,
+				Command:      "npm ci",
+				Sandbox:      true,
+				SandboxAllow: []string{"node_modules"},
+			},
+			expectError: true,
+		},
+		{
+			name: "copy hook with sandbox",
+			hook: Hook{
+				Type:    HookTypeCopy,
+				From:    ".env",
+				Sandbox: true,
+			},
+			expectError: true,
+		},
+		{
+			name: "copy hook with interactive field",
+			hook: Hook{
+				Type:        HookTypeCopy,
+				From:        ".env.example",
+				Interactive: true,
+			},
+			expectError: true,
+		},
 		{
 			name: "copy hook missing from",
 			hook: Hook{
@@ -481,6 +812,42 @@ func TestConfigValidate_CopyAbsoluteFromRequiresTo(t *testing.T) {
 	}
 }
 
+func TestConfigPullRequestRef(t *testing.T) {
+	if got := (&Config{}).PullRequestRef("42"); got != "refs/pull/42/head" {
+		t.Errorf("PullRequestRef() = %q, want the GitHub ref by default", got)
+	}
+
+	cfg := &Config{Defaults: Defaults{PRRefspec: "refs/merge-requests/{n}/head"}}
+	if got := cfg.PullRequestRef("7"); got != "refs/merge-requests/7/head" {
+		t.Errorf("PullRequestRef() = %q, want refs/merge-requests/7/head", got)
+	}
+}
+
+func TestConfigSparseProfile(t *testing.T) {
+	cfg := &Config{SparseProfiles: map[string][]string{
+		"web": {"apps/web", "libs/ui"},
+		"api": {"services/api"},
+	}}
+
+	directories, err := cfg.SparseProfile("web")
+	if err != nil {
+		t.Fatalf("SparseProfile() error = %v", err)
+	}
+	if strings.Join(directories, ",") != "apps/web,libs/ui" {
+		t.Errorf("SparseProfile() = %v, want [apps/web libs/ui]", directories)
+	}
+
+	_, err = cfg.SparseProfile("mobile")
+	if err == nil || !strings.Contains(err.Error(), "available profiles: api, web") {
+		t.Errorf("SparseProfile() error = %v, want the available profiles", err)
+	}
+
+	_, err = (&Config{}).SparseProfile("web")
+	if err == nil || !strings.Contains(err.Error(), "add it to sparse_profiles") {
+		t.Errorf("SparseProfile() error = %v, want a hint to define it", err)
+	}
+}
+
 func TestResolveWorktreePath(t *testing.T) {
 	tests := []struct {
 		name         string
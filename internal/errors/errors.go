@@ -168,6 +168,28 @@ func CannotRemoveCurrentWorktree(worktreeName, path string) error {
 	return errors.New(msg)
 }
 
+// CannotMoveCurrentWorktree indicates the user is trying to move the active worktree.
+func CannotMoveCurrentWorktree(worktreeName, path string) error {
+	msg := fmt.Sprintf("cannot move worktree '%s' while you are currently inside it", worktreeName)
+	msg += fmt.Sprintf("\n\nCurrent location: %s", path)
+	msg += "\n\nTip: Run 'wtp cd @' or 'wtp cd <another-worktree>' to switch before moving."
+	return errors.New(msg)
+}
+
+// PullRequestBranchDiverged reports that 'wtp add --pr' could not fast-forward the local
+// branch of a pull request, usually because the pull request was force-pushed.
+func PullRequestBranchDiverged(number, branch, remote, ref string) error {
+	msg := fmt.Sprintf(`pull request #%s can no longer fast-forward the local branch '%s'
+
+The pull request was probably force-pushed, or '%s' has local commits.
+
+Solutions:
+  • Delete the branch with 'git branch -D %s' and retry
+  • Reset the branch to the pull request with 'git fetch %s +%s:refs/heads/%s', discarding local commits`,
+		number, branch, branch, branch, remote, ref, branch)
+	return errors.New(msg)
+}
+
 // BranchRemovalFailed wraps errors that occur when deleting a git branch.
 func BranchRemovalFailed(branchName string, gitError error, isForced bool) error {
 	msg := fmt.Sprintf("failed to remove branch '%s'", branchName)
@@ -349,3 +371,13 @@ Solutions:
 	msg += fmt.Sprintf("\n\nOriginal error: %v", originalError)
 	return errors.New(msg)
 }
+
+// HooksNotTrusted reports that command hooks in .wtp.yml have not been approved to run.
+func HooksNotTrusted(retryCommand string) error {
+	msg := fmt.Sprintf(`command hooks in .wtp.yml are not trusted
+
+Solutions:
+  • Review the hooks above and run 'wtp trust', then retry
+  • Run '%s --trust' to run them once without recording trust (e.g. in CI)`, retryCommand)
+	return errors.New(msg)
+}
@@ -211,6 +211,24 @@ func TestCannotRemoveCurrentWorktree(t *testing.T) {
 	assert.Contains(t, err.Error(), "wtp cd @")
 }
 
+func TestCannotMoveCurrentWorktree(t *testing.T) {
+	err := CannotMoveCurrentWorktree("feature/foo", "/repo/.worktrees/feature/foo")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot move worktree 'feature/foo'")
+	assert.Contains(t, err.Error(), "Current location: /repo/.worktrees/feature/foo")
+	assert.Contains(t, err.Error(), "switch before moving")
+}
+
+func TestPullRequestBranchDiverged(t *testing.T) {
+	err := PullRequestBranchDiverged("42", "pr/42", "origin", "refs/pull/42/head")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "pull request #42 can no longer fast-forward the local branch 'pr/42'")
+	assert.Contains(t, err.Error(), "git branch -D pr/42")
+	assert.Contains(t, err.Error(), "git fetch origin +refs/pull/42/head:refs/heads/pr/42")
+}
+
 func TestBranchRemovalFailed(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -479,3 +497,12 @@ func TestErrorMessages_Format(t *testing.T) {
 		})
 	}
 }
+
+func TestHooksNotTrusted(t *testing.T) {
+	err := HooksNotTrusted("wtp add")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not trusted")
+	assert.Contains(t, err.Error(), "wtp trust")
+	assert.Contains(t, err.Error(), "wtp add --trust")
+}
@@ -0,0 +1,93 @@
+// Package events emits machine-readable progress events as newline-delimited JSON.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Event types emitted by 'wtp add'.
+const (
+	WorktreeCreated = "worktree_created"
+	HookStarted     = "hook_started"
+	HookOutput      = "hook_output"
+	HookFinished    = "hook_finished"
+	ExecFinished    = "exec_finished"
+)
+
+// Event is a single progress event. Fields that do not apply to an event type are omitted.
+type Event struct {
+	Type       string    `json:"type"`
+	Time       time.Time `json:"time"`
+	Worktree   string    `json:"worktree,omitempty"`
+	Branch     string    `json:"branch,omitempty"`
+	Hook       int       `json:"hook,omitempty"`
+	HookTotal  int       `json:"hook_total,omitempty"`
+	HookType   string    `json:"hook_type,omitempty"`
+	Command    string    `json:"command,omitempty"`
+	Line       string    `json:"line,omitempty"`
+	ExitCode   *int      `json:"exit_code,omitempty"`
+	DurationMS *int64    `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Emitter writes events to a writer, one JSON object per line.
+// A nil Emitter discards every event.
+type Emitter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewEmitter creates an emitter writing to w
+func NewEmitter(w io.Writer) *Emitter {
+	return &Emitter{w: w}
+}
+
+// Emit writes the event, stamping it with the current time when unset.
+// Write failures are ignored so that a disconnected consumer never aborts the command.
+func (e *Emitter) Emit(event Event) {
+	if e == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, _ = e.w.Write(append(data, '\n'))
+}
+
+// Finished fills in the exit code, duration and error of an event that closes a step started at started.
+func Finished(event Event, started time.Time, err error) Event {
+	code := ExitCode(err)
+	duration := time.Since(started).Milliseconds()
+	event.ExitCode = &code
+	event.DurationMS = &duration
+	if err != nil {
+		event.Error = err.Error()
+	}
+	return event
+}
+
+// ExitCode maps an error to a process-style exit code: 0 for nil, the
+// process exit status for command failures, and 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
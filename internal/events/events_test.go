@@ -0,0 +1,56 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitter_WritesNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	emitter := NewEmitter(&buf)
+
+	emitter.Emit(Event{Type: HookStarted, Hook: 1, HookTotal: 2, HookType: "command"})
+	emitter.Emit(Finished(Event{Type: HookFinished, Hook: 1}, time.Now(), errors.New("boom")))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var started map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &started))
+	assert.Equal(t, HookStarted, started["type"])
+	assert.InDelta(t, 2, started["hook_total"], 0)
+	assert.NotEmpty(t, started["time"])
+	assert.NotContains(t, started, "exit_code")
+
+	var finished map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &finished))
+	assert.InDelta(t, 1, finished["exit_code"], 0)
+	assert.Contains(t, finished, "duration_ms")
+	assert.Equal(t, "boom", finished["error"])
+}
+
+func TestEmitter_NilDiscards(t *testing.T) {
+	var emitter *Emitter
+	assert.NotPanics(t, func() { emitter.Emit(Event{Type: ExecFinished}) })
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.Equal(t, 1, ExitCode(errors.New("failed")))
+
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell commands")
+	}
+
+	err := exec.Command("sh", "-c", "exit 7").Run()
+	assert.Equal(t, 7, ExitCode(err))
+}
@@ -0,0 +1,74 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultWorktreeConfigKey is the git config key naming the worktree that stands in
+// for the main worktree of a bare repository. Relative paths are resolved from the
+// bare repository directory.
+const DefaultWorktreeConfigKey = "wtp.defaultWorktree"
+
+// MarkMainWorktree sets IsMain on the worktree wtp treats as the main one. That is
+// the first worktree git lists unless it is a bare repository, which has no working
+// tree of its own. Then it is the worktree named by defaultWorktree, else the one on
+// main or master, else the first one with a working tree. Nothing is marked when a
+// bare repository has no usable worktrees.
+func MarkMainWorktree(worktrees []Worktree, defaultWorktree string) {
+	for i := range worktrees {
+		worktrees[i].IsMain = false
+	}
+	if len(worktrees) == 0 {
+		return
+	}
+	if !worktrees[0].Bare {
+		worktrees[0].IsMain = true
+		return
+	}
+	if i := defaultWorktreeIndex(worktrees, defaultWorktree); i >= 0 {
+		worktrees[i].IsMain = true
+	}
+}
+
+func defaultWorktreeIndex(worktrees []Worktree, defaultWorktree string) int {
+	usable := func(wt Worktree) bool { return !wt.Bare && !wt.Prunable }
+
+	if defaultWorktree != "" {
+		if !filepath.IsAbs(defaultWorktree) {
+			defaultWorktree = filepath.Join(worktrees[0].Path, defaultWorktree)
+		}
+		for i, wt := range worktrees {
+			if usable(wt) && filepath.Clean(wt.Path) == filepath.Clean(defaultWorktree) {
+				return i
+			}
+		}
+	}
+
+	for _, branch := range []string{MainBranch, MasterBranch} {
+		for i, wt := range worktrees {
+			if usable(wt) && !wt.Detached && wt.Branch == branch {
+				return i
+			}
+		}
+	}
+
+	for i, wt := range worktrees {
+		if usable(wt) {
+			return i
+		}
+	}
+	return -1
+}
+
+// configValue returns the value of a git config key, or "" when it is not set.
+func (r *Repository) configValue(key string) string {
+	cmd := exec.Command("git", "config", "--get", key)
+	cmd.Dir = r.path
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
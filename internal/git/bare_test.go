@@ -0,0 +1,120 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkMainWorktree(t *testing.T) {
+	bareLayout := func() []Worktree {
+		return []Worktree{
+			{Path: "/src/proj/.bare", Bare: true},
+			{Path: "/src/proj/worktrees/feature", Branch: "feature"},
+			{Path: "/src/proj/gone", Branch: "main", Prunable: true},
+			{Path: "/src/proj/trunk", Branch: "master"},
+			{Path: "/src/proj/stable", Branch: "stable"},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		worktrees       []Worktree
+		defaultWorktree string
+		expected        string
+	}{
+		{
+			name: "first worktree of a regular repository",
+			worktrees: []Worktree{
+				{Path: "/src/proj", Branch: "develop"},
+				{Path: "/src/worktrees/main", Branch: "main"},
+			},
+			defaultWorktree: "../stable",
+			expected:        "/src/proj",
+		},
+		{
+			name:            "configured relative to the bare repository",
+			worktrees:       bareLayout(),
+			defaultWorktree: "../stable",
+			expected:        "/src/proj/stable",
+		},
+		{
+			name:            "configured as an absolute path",
+			worktrees:       bareLayout(),
+			defaultWorktree: "/src/proj/worktrees/feature/",
+			expected:        "/src/proj/worktrees/feature",
+		},
+		{
+			name:            "main or master when unconfigured or missing, skipping prunable worktrees",
+			worktrees:       bareLayout(),
+			defaultWorktree: "../removed",
+			expected:        "/src/proj/trunk",
+		},
+		{
+			name: "first checkout otherwise",
+			worktrees: []Worktree{
+				{Path: "/src/proj/.bare", Bare: true},
+				{Path: "/src/proj/feature", Branch: "feature"},
+			},
+			expected: "/src/proj/feature",
+		},
+		{
+			name:      "nothing in a bare repository without worktrees",
+			worktrees: []Worktree{{Path: "/src/proj.git", Bare: true, IsMain: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			MarkMainWorktree(tt.worktrees, tt.defaultWorktree)
+
+			var marked []string
+			for _, wt := range tt.worktrees {
+				if wt.IsMain {
+					marked = append(marked, wt.Path)
+				}
+			}
+			if tt.expected == "" {
+				assert.Empty(t, marked)
+				return
+			}
+			assert.Equal(t, []string{tt.expected}, marked)
+		})
+	}
+}
+
+func TestGetMainWorktreePath_BareRepository(t *testing.T) {
+	source := setupTestRepo(t)
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	bareDir := filepath.Join(root, "proj.git")
+	runCmd(t, root, "git", "clone", "--bare", source, bareDir)
+	runCmd(t, bareDir, "git", "branch", "stable", "main")
+	runCmd(t, bareDir, "git", "worktree", "add", filepath.Join(root, "main"), "main")
+	runCmd(t, bareDir, "git", "worktree", "add", filepath.Join(root, "stable"), "stable")
+
+	mainPath := func(dir string) string {
+		t.Helper()
+		repo, err := NewRepository(dir)
+		require.NoError(t, err)
+		path, err := repo.GetMainWorktreePath()
+		require.NoError(t, err)
+		return path
+	}
+
+	// Without configuration the worktree on main stands in for the main worktree,
+	// rather than the parent of proj.git.
+	assert.Equal(t, filepath.Join(root, "main"), mainPath(bareDir))
+	assert.Equal(t, filepath.Join(root, "main"), mainPath(filepath.Join(root, "stable")))
+
+	runCmd(t, bareDir, "git", "config", DefaultWorktreeConfigKey, "../stable")
+	assert.Equal(t, filepath.Join(root, "stable"), mainPath(filepath.Join(root, "main")))
+
+	// Without any worktree the bare repository itself is returned.
+	runCmd(t, bareDir, "git", "worktree", "remove", filepath.Join(root, "main"))
+	runCmd(t, bareDir, "git", "worktree", "remove", filepath.Join(root, "stable"))
+	assert.Equal(t, bareDir, mainPath(bareDir))
+}
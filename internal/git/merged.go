@@ -0,0 +1,150 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// MergeState describes whether and how a branch was merged into another.
+type MergeState int
+
+const (
+	// NotMerged branches have changes that are not in the target.
+	NotMerged MergeState = iota
+	// Merged branches are ancestors of the target, e.g. after a merge commit or fast-forward.
+	Merged
+	// Rebased branches have every commit in the target as an equivalent patch, e.g. after a rebase merge.
+	Rebased
+	// Squashed branches have all their changes in a single commit of the target.
+	Squashed
+)
+
+func (s MergeState) String() string {
+	switch s {
+	case Merged:
+		return "merged"
+	case Rebased:
+		return "rebase-merged"
+	case Squashed:
+		return "squash-merged"
+	default:
+		return "not merged"
+	}
+}
+
+// BranchMergeState reports whether branch is merged into target. Besides
+// ancestry it compares patch ids with 'git cherry', so branches that were
+// rebased or squashed onto target count as merged too. A branch without
+// commits of its own, e.g. one just created, is never merged.
+func BranchMergeState(repoPath, branch, target string) (MergeState, error) {
+	ancestor := exec.Command("git", "merge-base", "--is-ancestor", branch, target)
+	ancestor.Dir = repoPath
+	err := ancestor.Run()
+	if err == nil {
+		own, err := hasOwnCommits(repoPath, branch, target)
+		if err != nil || !own {
+			return NotMerged, err
+		}
+		return Merged, nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		return NotMerged, fmt.Errorf("failed to compare %s with %s: %w", branch, target, err)
+	}
+
+	upstream, err := allCommitsUpstream(repoPath, target, branch)
+	if err != nil {
+		return NotMerged, err
+	}
+	if upstream {
+		return Rebased, nil
+	}
+
+	squash, err := squashCommit(repoPath, branch, target)
+	if err != nil {
+		return NotMerged, err
+	}
+	upstream, err = allCommitsUpstream(repoPath, target, squash)
+	if err != nil || !upstream {
+		return NotMerged, err
+	}
+	return Squashed, nil
+}
+
+// hasOwnCommits reports whether branch moved past the commit it was created from.
+// The branch reflog records that commit; without a reflog, e.g. in a bare
+// repository, a branch still at the tip of target has no commits of its own.
+func hasOwnCommits(repoPath, branch, target string) (bool, error) {
+	tip, err := revParse(repoPath, branch)
+	if err != nil {
+		return false, err
+	}
+
+	reflog := exec.Command("git", "reflog", "show", "--format=%H %gs", "refs/heads/"+branch, "--")
+	reflog.Dir = repoPath
+	if output, err := reflog.Output(); err == nil && len(strings.TrimSpace(string(output))) > 0 {
+		entries := strings.Split(strings.TrimSpace(string(output)), "\n")
+		created, message, _ := strings.Cut(entries[len(entries)-1], " ")
+		return created != tip || !strings.HasPrefix(message, "branch: Created from"), nil
+	}
+
+	targetTip, err := revParse(repoPath, target)
+	if err != nil {
+		return false, err
+	}
+	return tip != targetTip, nil
+}
+
+func revParse(repoPath, rev string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", rev, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// allCommitsUpstream reports whether every commit of head that is not in target
+// has an equivalent change in target, as 'git cherry' marks them with "-".
+func allCommitsUpstream(repoPath, target, head string) (bool, error) {
+	cmd := exec.Command("git", "cherry", target, head)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to compare %s with %s: %w", head, target, err)
+	}
+	lines := strings.Fields(string(output))
+	for i := 0; i < len(lines); i += 2 {
+		if lines[i] != "-" {
+			return false, nil
+		}
+	}
+	return len(lines) > 0, nil
+}
+
+// squashCommit creates a dangling commit holding all changes of branch since it
+// forked from target, so that 'git cherry' can find a squash merge of them.
+func squashCommit(repoPath, branch, target string) (string, error) {
+	base := exec.Command("git", "merge-base", target, branch)
+	base.Dir = repoPath
+	mergeBase, err := base.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", branch, target, err)
+	}
+
+	cmd := exec.Command("git", "commit-tree", branch+"^{tree}", "-p", strings.TrimSpace(string(mergeBase)),
+		"-m", "wtp squash of "+branch)
+	cmd.Dir = repoPath
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=wtp", "GIT_AUTHOR_EMAIL=wtp@localhost",
+		"GIT_COMMITTER_NAME=wtp", "GIT_COMMITTER_EMAIL=wtp@localhost")
+	commit, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to compare %s with %s: %w", branch, target, err)
+	}
+	return strings.TrimSpace(string(commit)), nil
+}
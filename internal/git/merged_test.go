@@ -0,0 +1,75 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchMergeState(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+	commit := func(file string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, file), []byte(file), 0o600))
+		run("add", file)
+		run("commit", "-m", "Add "+file)
+	}
+	branch := func(name string, files ...string) {
+		t.Helper()
+		run("checkout", "-b", name, "main")
+		for _, file := range files {
+			commit(file)
+		}
+		run("checkout", "main")
+	}
+
+	branch("merged", "merged.txt")
+	branch("rebased", "rebased-1.txt", "rebased-2.txt")
+	branch("squashed", "squashed-1.txt", "squashed-2.txt")
+	branch("unmerged", "unmerged.txt")
+	branch("partly", "partly-1.txt", "partly-2.txt")
+	branch("fresh")
+
+	run("merge", "--no-ff", "-m", "Merge merged", "merged")
+	run("cherry-pick", "main..rebased")
+	run("merge", "--squash", "squashed")
+	run("commit", "-m", "Squash squashed")
+	run("cherry-pick", run("rev-parse", "partly~1"))
+	run("branch", "behind", "main~1")
+	run("-c", "core.logAllRefUpdates=false", "branch", "no-reflog", "main")
+
+	tests := map[string]MergeState{
+		"merged":   Merged,
+		"rebased":  Rebased,
+		"squashed": Squashed,
+		"unmerged": NotMerged,
+		"partly":   NotMerged,
+		"fresh":    NotMerged,
+		"behind":   NotMerged,
+		// Without a reflog only a branch at the tip of main is known to have no commits.
+		"no-reflog": NotMerged,
+	}
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			state, err := BranchMergeState(repoDir, name, "main")
+			require.NoError(t, err)
+			assert.Equal(t, expected, state, state.String())
+		})
+	}
+
+	_, err := BranchMergeState(repoDir, "does-not-exist", "main")
+	assert.Error(t, err)
+}
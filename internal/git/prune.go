@@ -0,0 +1,183 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// OrphanedWorktreeDirs returns the directories beneath baseDir that were linked
+// worktrees of the repository at repoPath but are no longer registered with git:
+// their .git file points at an administrative directory that no longer exists.
+// Worktrees of other repositories and unrelated directories are left alone.
+func OrphanedWorktreeDirs(repoPath, baseDir string) ([]string, error) {
+	commonDir, err := commonDir(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// WalkDir does not follow a symlinked root, e.g. a base_dir linked to another disk.
+	if resolved, err := filepath.EvalSymlinks(baseDir); err == nil {
+		baseDir = resolved
+	}
+
+	var orphans []string
+	err = filepath.WalkDir(baseDir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if path == baseDir && errors.Is(walkErr, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return walkErr
+		}
+		if !d.IsDir() || path == baseDir {
+			return nil
+		}
+
+		info, err := os.Lstat(filepath.Join(path, ".git"))
+		if err != nil {
+			// Not a worktree; keep looking beneath it, e.g. in "feature/" of "feature/auth".
+			return nil
+		}
+		if !info.IsDir() && isOrphanedGitDir(path, commonDir) {
+			orphans = append(orphans, path)
+		}
+		return fs.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s for orphaned worktrees: %w", baseDir, err)
+	}
+	return orphans, nil
+}
+
+// isOrphanedGitDir reports whether the .git file of the directory at path points
+// into commonDir/worktrees at an administrative directory that is gone.
+func isOrphanedGitDir(path, commonDir string) bool {
+	gitDir, err := WorktreeGitDir(path)
+	if err != nil {
+		return false
+	}
+	if _, err := os.Stat(gitDir); !errors.Is(err, fs.ErrNotExist) {
+		return false
+	}
+
+	worktreesDir := filepath.Dir(gitDir)
+	if filepath.Base(worktreesDir) != "worktrees" {
+		return false
+	}
+	owner, err := filepath.EvalSymlinks(filepath.Dir(worktreesDir))
+	return err == nil && owner == commonDir
+}
+
+// commonDir returns the absolute, symlink-free git common directory of the repository at repoPath.
+func commonDir(repoPath string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get git common directory: %w", err)
+	}
+
+	dir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(repoPath, dir)
+	}
+	return filepath.EvalSymlinks(dir)
+}
+
+// MergedBranches returns the local branches whose tips are reachable from base.
+func MergedBranches(repoPath, base string) ([]string, error) {
+	cmd := exec.Command("git", "for-each-ref", "--merged", base, "--format=%(refname:short)", "refs/heads/")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches merged into %s: %w", base, err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// leftoverBranchesPath returns the file, inside the git common directory, that
+// lists the branches whose worktrees wtp removed while keeping the branch.
+func leftoverBranchesPath(repoPath string) (string, error) {
+	dir, err := commonDir(repoPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "wtp", "leftover-branches"), nil
+}
+
+// RecordLeftoverBranch notes that the worktree of branch was removed but the branch
+// kept, which makes the branch a candidate for 'wtp prune' once it is merged.
+func RecordLeftoverBranch(repoPath, branch string) error {
+	branches, err := LeftoverBranches(repoPath)
+	if err != nil {
+		return err
+	}
+	for _, recorded := range branches {
+		if recorded == branch {
+			return nil
+		}
+	}
+	return writeLeftoverBranches(repoPath, append(branches, branch))
+}
+
+// LeftoverBranches returns the branches recorded by RecordLeftoverBranch.
+func LeftoverBranches(repoPath string) ([]string, error) {
+	path, err := leftoverBranchesPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	// #nosec G304 -- path is a fixed file inside the repository's git common directory
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return strings.Fields(string(content)), nil
+}
+
+// ForgetLeftoverBranches removes branches from the record, e.g. once they are deleted.
+func ForgetLeftoverBranches(repoPath string, branches []string) error {
+	if len(branches) == 0 {
+		return nil
+	}
+	recorded, err := LeftoverBranches(repoPath)
+	if err != nil {
+		return err
+	}
+
+	forget := make(map[string]bool, len(branches))
+	for _, branch := range branches {
+		forget[branch] = true
+	}
+	kept := recorded[:0]
+	for _, branch := range recorded {
+		if !forget[branch] {
+			kept = append(kept, branch)
+		}
+	}
+	return writeLeftoverBranches(repoPath, kept)
+}
+
+func writeLeftoverBranches(repoPath string, branches []string) error {
+	path, err := leftoverBranchesPath(repoPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to record leftover branches: %w", err)
+	}
+	var content strings.Builder
+	for _, branch := range branches {
+		content.WriteString(branch + "\n")
+	}
+	if err := os.WriteFile(path, []byte(content.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to record leftover branches: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrphanedWorktreeDirs(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	otherRepoDir := setupTestRepo(t)
+	baseDir := filepath.Join(t.TempDir(), "worktrees")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	assert.Empty(t, mustOrphans(t, repoDir, baseDir), "a missing base_dir has no orphans")
+
+	run(repoDir, "worktree", "add", "-b", "feature/live", filepath.Join(baseDir, "feature", "live"))
+	run(repoDir, "worktree", "add", "-b", "feature/ghost", filepath.Join(baseDir, "feature", "ghost"))
+	run(otherRepoDir, "worktree", "add", "-b", "other", filepath.Join(baseDir, "other"))
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "notes"), 0o750))
+
+	// Forget the ghost and the other repository's worktree as if their metadata had been deleted.
+	require.NoError(t, os.RemoveAll(filepath.Join(repoDir, ".git", "worktrees", "ghost")))
+	require.NoError(t, os.RemoveAll(filepath.Join(otherRepoDir, ".git", "worktrees", "other")))
+
+	orphans := mustOrphans(t, repoDir, baseDir)
+	resolvedBase, err := filepath.EvalSymlinks(baseDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(resolvedBase, "feature", "ghost")}, orphans)
+}
+
+func mustOrphans(t *testing.T, repoDir, baseDir string) []string {
+	t.Helper()
+	orphans, err := OrphanedWorktreeDirs(repoDir, baseDir)
+	require.NoError(t, err)
+	return orphans
+}
+
+func TestMergedBranches(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	run("branch", "merged")
+	run("checkout", "-b", "unmerged")
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "feature.txt"), []byte("feature"), 0o600))
+	run("add", "feature.txt")
+	run("commit", "-m", "Add feature")
+	run("checkout", "main")
+
+	branches, err := MergedBranches(repoDir, "main")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"main", "merged"}, branches)
+
+	_, err = MergedBranches(repoDir, "does-not-exist")
+	assert.Error(t, err)
+}
+
+func TestLeftoverBranches(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	worktree := filepath.Join(t.TempDir(), "feature")
+	runCmd(t, repoDir, "git", "worktree", "add", "-b", "feature/a", worktree)
+
+	branches, err := LeftoverBranches(repoDir)
+	require.NoError(t, err)
+	assert.Empty(t, branches)
+
+	// Linked worktrees share the record of the repository.
+	require.NoError(t, RecordLeftoverBranch(worktree, "feature/a"))
+	require.NoError(t, RecordLeftoverBranch(repoDir, "feature/b"))
+	require.NoError(t, RecordLeftoverBranch(repoDir, "feature/a"))
+
+	branches, err = LeftoverBranches(repoDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feature/a", "feature/b"}, branches)
+
+	require.NoError(t, ForgetLeftoverBranches(repoDir, []string{"feature/a", "unknown"}))
+	branches, err = LeftoverBranches(worktree)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feature/b"}, branches)
+}
@@ -35,7 +35,9 @@ func (r *Repository) GetRepositoryName() string {
 }
 
 // GetMainWorktreePath returns the path to the main worktree (original repository)
-// This is useful when running commands from within a worktree
+// This is useful when running commands from within a worktree. In a bare
+// repository it returns the default worktree (see MarkMainWorktree), or the bare
+// repository itself when it has no worktrees.
 func (r *Repository) GetMainWorktreePath() (string, error) {
 	// Get the common directory which points to the main repository's .git
 	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
@@ -46,29 +48,26 @@ func (r *Repository) GetMainWorktreePath() (string, error) {
 	}
 
 	commonDir := strings.TrimSpace(string(output))
-
-	// If commonDir ends with .git, get its parent directory
-	if strings.HasSuffix(commonDir, ".git") {
-		// Get the parent directory of .git
-		parent := filepath.Dir(commonDir)
-		// Convert to absolute path if needed
-		if !filepath.IsAbs(parent) {
-			absPath, absErr := filepath.Abs(filepath.Join(r.path, parent))
-			if absErr != nil {
-				return "", fmt.Errorf("failed to get absolute path: %w", absErr)
-			}
-			return absPath, nil
-		}
-		return parent, nil
-	}
-
-	// If commonDir doesn't end with .git, it's likely already the worktree path
 	if !filepath.IsAbs(commonDir) {
 		absPath, absErr := filepath.Abs(filepath.Join(r.path, commonDir))
 		if absErr != nil {
 			return "", fmt.Errorf("failed to get absolute path: %w", absErr)
 		}
-		return absPath, nil
+		commonDir = absPath
+	}
+
+	// The main worktree holds the common .git directory
+	if filepath.Base(commonDir) == ".git" {
+		return filepath.Dir(commonDir), nil
+	}
+
+	// Otherwise the repository is bare or uses a separate git directory, so ask git
+	if worktrees, err := r.GetWorktrees(); err == nil {
+		for _, wt := range worktrees {
+			if wt.IsMain {
+				return wt.Path, nil
+			}
+		}
 	}
 
 	return commonDir, nil
@@ -85,10 +84,11 @@ func (r *Repository) GetWorktrees() ([]Worktree, error) {
 
 	worktrees := parseWorktreeList(string(output))
 
-	// The first worktree in the list is always the main worktree
-	if len(worktrees) > 0 {
-		worktrees[0].IsMain = true
+	defaultWorktree := ""
+	if len(worktrees) > 0 && worktrees[0].Bare {
+		defaultWorktree = r.configValue(DefaultWorktreeConfigKey)
 	}
+	MarkMainWorktree(worktrees, defaultWorktree)
 
 	return worktrees, nil
 }
@@ -237,6 +237,17 @@ func (r *Repository) ResolveBranch(branch string) (resolvedBranch string, isRemo
 	return "", false, nil
 }
 
+// HeadCommit returns the full hash of the commit checked out in the worktree at path.
+func HeadCommit(path string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = path
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD in %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 func isGitRepository(path string) bool {
 	// Use git rev-parse to check if we're in a git repository
 	// This works for both regular repos and worktrees
@@ -272,6 +283,8 @@ func parseWorktreeList(output string) []Worktree {
 				current.HEAD = after
 			} else if after, found := strings.CutPrefix(line, "branch refs/heads/"); found {
 				current.Branch = after
+			} else {
+				current.ParseStateLine(line)
 			}
 		}
 	}
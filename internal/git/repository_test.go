@@ -141,6 +141,40 @@ branch refs/heads/feature/test
 				},
 			},
 		},
+		{
+			name: "bare, detached, locked and prunable worktrees",
+			output: `worktree /path/to/repo.git
+bare
+
+worktree /path/to/spike
+HEAD efgh5678
+detached
+locked
+
+worktree /media/usb/feature
+HEAD ijkl9012
+branch refs/heads/feature
+locked "on a \"usb\" drive"
+
+worktree /path/to/gone
+HEAD mnop3456
+branch refs/heads/gone
+prunable gitdir file points to non-existent location
+
+`,
+			expected: []Worktree{
+				{Path: "/path/to/repo.git", Bare: true},
+				{Path: "/path/to/spike", HEAD: "efgh5678", Detached: true, Locked: true},
+				{
+					Path: "/media/usb/feature", HEAD: "ijkl9012", Branch: "feature",
+					Locked: true, LockReason: `on a "usb" drive`,
+				},
+				{
+					Path: "/path/to/gone", HEAD: "mnop3456", Branch: "gone",
+					Prunable: true, PrunableReason: "gitdir file points to non-existent location",
+				},
+			},
+		},
 		{
 			name:     "empty output",
 			output:   "",
@@ -167,6 +201,9 @@ branch refs/heads/feature/test
 				if result[i].Branch != expected.Branch {
 					t.Errorf("Worktree %d: expected branch %s, got %s", i, expected.Branch, result[i].Branch)
 				}
+				if result[i] != expected {
+					t.Errorf("Worktree %d: expected %+v, got %+v", i, expected, result[i])
+				}
 			}
 		})
 	}
@@ -261,6 +298,29 @@ func TestIsGitRepository(t *testing.T) {
 	}
 }
 
+func TestHeadCommit(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	head, err := HeadCommit(repoDir)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Failed to resolve HEAD: %v", err)
+	}
+	if expected := strings.TrimSpace(string(output)); head != expected {
+		t.Errorf("Expected HEAD %s, got %s", expected, head)
+	}
+
+	if _, err := HeadCommit(t.TempDir()); err == nil {
+		t.Error("Expected error for non-git directory, got nil")
+	}
+}
+
 func TestBranchResolution(t *testing.T) {
 	// Create a temporary directory for test repository
 	repoDir := setupTestRepo(t)
@@ -0,0 +1,45 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SparseProfilePath returns where the sparse-checkout profile of a worktree is
+// recorded inside its administrative git directory.
+func SparseProfilePath(gitDir string) string {
+	return filepath.Join(gitDir, "wtp", "sparse-profile")
+}
+
+// WriteSparseProfile records profile as the sparse-checkout profile of the worktree at path.
+func WriteSparseProfile(path, profile string) error {
+	gitDir, err := WorktreeGitDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to find the git directory of %s: %w", path, err)
+	}
+	profilePath := SparseProfilePath(gitDir)
+	if err := os.MkdirAll(filepath.Dir(profilePath), 0o750); err != nil {
+		return fmt.Errorf("failed to record sparse profile: %w", err)
+	}
+	if err := os.WriteFile(profilePath, []byte(profile+"\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to record sparse profile: %w", err)
+	}
+	return nil
+}
+
+// ReadSparseProfile returns the sparse-checkout profile recorded for the worktree
+// at path, or "" when it was checked out in full.
+func ReadSparseProfile(path string) string {
+	gitDir, err := WorktreeGitDir(path)
+	if err != nil {
+		return ""
+	}
+	// #nosec G304 -- the path is inside the administrative directory of a listed worktree
+	content, err := os.ReadFile(SparseProfilePath(gitDir))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
@@ -0,0 +1,25 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparseProfile(t *testing.T) {
+	repo := setupTestRepo(t)
+	worktree := filepath.Join(t.TempDir(), "web")
+	runCmd(t, repo, "git", "worktree", "add", "--no-checkout", "-b", "web", worktree)
+
+	assert.Empty(t, ReadSparseProfile(worktree))
+	assert.Empty(t, ReadSparseProfile(filepath.Join(t.TempDir(), "missing")))
+
+	require.NoError(t, WriteSparseProfile(worktree, "frontend"))
+	assert.Equal(t, "frontend", ReadSparseProfile(worktree))
+	assert.FileExists(t, filepath.Join(repo, ".git", "worktrees", "web", "wtp", "sparse-profile"))
+
+	// The record belongs to the linked worktree, not to the main one.
+	assert.Empty(t, ReadSparseProfile(repo))
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status summarizes the state of a worktree for 'wtp list --status'.
+type Status struct {
+	// Changed counts staged, unstaged and conflicting paths; Untracked counts untracked ones.
+	Changed   int
+	Untracked int
+	// Upstream is the upstream branch of the checked out branch, or empty without one.
+	Upstream string
+	Ahead    int
+	Behind   int
+	// BaseRef is the default branch the worktree was compared with, or empty when unknown.
+	BaseRef    string
+	BaseAhead  int
+	BaseBehind int
+	// LastCommitTime and LastCommitSubject describe HEAD; both are zero without commits.
+	LastCommitTime    time.Time
+	LastCommitSubject string
+}
+
+// DefaultBranch returns the ref that worktrees are compared with: the remote HEAD
+// of origin (e.g. "origin/main") or else a local main or master branch. It returns
+// an empty string when there is none.
+func DefaultBranch(repoPath string) string {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	cmd.Dir = repoPath
+	if output, err := cmd.Output(); err == nil {
+		return strings.TrimSpace(string(output))
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
+		cmd.Dir = repoPath
+		if cmd.Run() == nil {
+			return branch
+		}
+	}
+	return ""
+}
+
+// WorktreeStatus gathers the status of the worktree at path. baseRef, usually
+// from DefaultBranch, is compared with HEAD unless it is empty.
+func WorktreeStatus(path, baseRef string) (Status, error) {
+	cmd := exec.Command("git", "status", "--porcelain=v2", "--branch")
+	cmd.Dir = path
+	output, err := cmd.Output()
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to get status of %s: %w", path, err)
+	}
+	status := parseStatusPorcelain(string(output))
+
+	if baseRef != "" {
+		cmd := exec.Command("git", "rev-list", "--left-right", "--count", baseRef+"...HEAD")
+		cmd.Dir = path
+		if output, err := cmd.Output(); err == nil {
+			if behind, ahead, ok := parseLeftRightCount(string(output)); ok {
+				status.BaseRef, status.BaseBehind, status.BaseAhead = baseRef, behind, ahead
+			}
+		}
+	}
+
+	// A repository without commits has no last commit; that is not an error.
+	cmd = exec.Command("git", "log", "-1", "--format=%ct%x00%s")
+	cmd.Dir = path
+	if output, err := cmd.Output(); err == nil {
+		timestamp, subject, _ := strings.Cut(strings.TrimRight(string(output), "\n"), "\x00")
+		if seconds, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+			status.LastCommitTime = time.Unix(seconds, 0)
+			status.LastCommitSubject = subject
+		}
+	}
+
+	return status, nil
+}
+
+// parseStatusPorcelain reads 'git status --porcelain=v2 --branch' output.
+func parseStatusPorcelain(output string) Status {
+	var status Status
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.upstream "):
+			status.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fields := strings.Fields(strings.TrimPrefix(line, "# branch.ab "))
+			if len(fields) == 2 { //nolint:mnd // "+ahead -behind"
+				status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+				status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+			}
+		case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "), strings.HasPrefix(line, "u "):
+			status.Changed++
+		case strings.HasPrefix(line, "? "):
+			status.Untracked++
+		}
+	}
+	return status
+}
+
+// parseLeftRightCount reads 'git rev-list --left-right --count' output.
+func parseLeftRightCount(output string) (left, right int, ok bool) {
+	fields := strings.Fields(output)
+	if len(fields) != 2 { //nolint:mnd // left and right counts
+		return 0, 0, false
+	}
+	left, leftErr := strconv.Atoi(fields[0])
+	right, rightErr := strconv.Atoi(fields[1])
+	return left, right, leftErr == nil && rightErr == nil
+}
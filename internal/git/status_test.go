@@ -0,0 +1,75 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatusPorcelain(t *testing.T) {
+	output := "# branch.oid 1234567890abcdef\n" +
+		"# branch.head feature/auth\n" +
+		"# branch.upstream origin/feature/auth\n" +
+		"# branch.ab +2 -1\n" +
+		"1 .M N... 100644 100644 100644 abc abc README.md\n" +
+		"2 R. N... 100644 100644 100644 abc abc R100 new.go\told.go\n" +
+		"u UU N... 100644 100644 100644 100644 abc abc abc conflict.txt\n" +
+		"? notes.txt\n" +
+		"? tmp/\n"
+
+	status := parseStatusPorcelain(output)
+	assert.Equal(t, Status{Changed: 3, Untracked: 2, Upstream: "origin/feature/auth", Ahead: 2, Behind: 1}, status)
+	assert.Equal(t, Status{}, parseStatusPorcelain("# branch.oid (initial)\n# branch.head main\n"))
+}
+
+func TestParseLeftRightCount(t *testing.T) {
+	left, right, ok := parseLeftRightCount("3\t5\n")
+	assert.True(t, ok)
+	assert.Equal(t, 3, left)
+	assert.Equal(t, 5, right)
+
+	_, _, ok = parseLeftRightCount("garbage")
+	assert.False(t, ok)
+}
+
+func TestWorktreeStatus(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	assert.Equal(t, "main", DefaultBranch(repoDir))
+
+	run("checkout", "-b", "feature")
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "feature.txt"), []byte("feature"), 0o600))
+	run("add", "feature.txt")
+	run("commit", "-m", "Add feature")
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("changed"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "untracked.txt"), []byte("new"), 0o600))
+
+	status, err := WorktreeStatus(repoDir, "main")
+	require.NoError(t, err)
+	assert.Equal(t, 1, status.Changed)
+	assert.Equal(t, 1, status.Untracked)
+	assert.Empty(t, status.Upstream)
+	assert.Equal(t, "main", status.BaseRef)
+	assert.Equal(t, 1, status.BaseAhead)
+	assert.Equal(t, 0, status.BaseBehind)
+	assert.Equal(t, "Add feature", status.LastCommitSubject)
+	assert.False(t, status.LastCommitTime.IsZero())
+
+	status, err = WorktreeStatus(repoDir, "does-not-exist")
+	require.NoError(t, err)
+	assert.Empty(t, status.BaseRef, "unknown base refs are left out")
+
+	_, err = WorktreeStatus(t.TempDir(), "")
+	assert.Error(t, err)
+}
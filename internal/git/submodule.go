@@ -0,0 +1,61 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Submodule is a submodule declared in .gitmodules.
+type Submodule struct {
+	Name string
+	Path string
+}
+
+// Submodules lists the submodules declared in the .gitmodules file of worktreePath.
+func Submodules(worktreePath string) ([]Submodule, error) {
+	if _, err := os.Stat(filepath.Join(worktreePath, ".gitmodules")); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	cmd := exec.Command("git", "config", "--file", ".gitmodules", "--get-regexp", `^submodule\..*\.path$`)
+	cmd.Dir = worktreePath
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read .gitmodules in %s: %w", worktreePath, err)
+	}
+
+	var submodules []Submodule
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		key, path, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "submodule."), ".path")
+		submodules = append(submodules, Submodule{Name: name, Path: path})
+	}
+	return submodules, nil
+}
+
+// SubmoduleReference returns the git directory of the named submodule in the main
+// worktree, which new worktrees can borrow objects from with --reference, or ""
+// when the main worktree has not initialized it.
+func SubmoduleReference(worktreePath, name string) string {
+	common, err := commonDir(worktreePath)
+	if err != nil {
+		return ""
+	}
+	dir := filepath.Join(common, "modules", name)
+	if info, err := os.Stat(filepath.Join(dir, "objects")); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
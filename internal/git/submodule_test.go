@@ -0,0 +1,39 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmodules(t *testing.T) {
+	// Submodules are cloned from local paths, which git refuses by default.
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+
+	library := setupTestRepo(t)
+	repo := setupTestRepo(t)
+
+	none, err := Submodules(repo)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	runCmd(t, repo, "git", "submodule", "add", library, "libs/lib")
+	runCmd(t, repo, "git", "commit", "-m", "Add lib")
+
+	worktree := filepath.Join(t.TempDir(), "feature")
+	runCmd(t, repo, "git", "worktree", "add", "-b", "feature", worktree)
+
+	submodules, err := Submodules(worktree)
+	require.NoError(t, err)
+	assert.Equal(t, []Submodule{{Name: "libs/lib", Path: "libs/lib"}}, submodules)
+
+	reference := SubmoduleReference(worktree, "libs/lib")
+	expected, err := filepath.EvalSymlinks(filepath.Join(repo, ".git", "modules", "libs", "lib"))
+	require.NoError(t, err)
+	assert.Equal(t, expected, reference)
+	assert.Empty(t, SubmoduleReference(worktree, "missing"))
+}
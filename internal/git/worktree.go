@@ -3,7 +3,9 @@ package git
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -20,6 +22,46 @@ type Worktree struct {
 	Branch string
 	HEAD   string
 	IsMain bool // True if this is the main/root worktree
+	// Bare is set for the repository entry of a bare repository, which has no working tree.
+	Bare     bool
+	Detached bool
+	// Locked worktrees are kept by 'git worktree prune' and need a double --force to remove.
+	Locked     bool
+	LockReason string
+	// Prunable worktrees are gone from disk and will be removed by 'git worktree prune'.
+	Prunable       bool
+	PrunableReason string
+	// SparseProfile is the sparse-checkout profile 'wtp add --sparse' applied, if any.
+	SparseProfile string
+}
+
+// ParseStateLine records a bare, detached, locked or prunable line of
+// 'git worktree list --porcelain' output and reports whether line was one.
+func (w *Worktree) ParseStateLine(line string) bool {
+	keyword, reason, _ := strings.Cut(line, " ")
+	switch keyword {
+	case "bare":
+		w.Bare = true
+	case "detached":
+		w.Detached = true
+	case "locked":
+		w.Locked, w.LockReason = true, unquoteReason(reason)
+	case "prunable":
+		w.Prunable, w.PrunableReason = true, unquoteReason(reason)
+	default:
+		return false
+	}
+	return true
+}
+
+// unquoteReason undoes the C-style quoting git applies to reasons with special characters.
+func unquoteReason(reason string) string {
+	if strings.HasPrefix(reason, `"`) {
+		if unquoted, err := strconv.Unquote(reason); err == nil {
+			return unquoted
+		}
+	}
+	return reason
 }
 
 // Name returns the directory name of the worktree path.
@@ -94,3 +136,32 @@ func (w *Worktree) IsMainWorktree(mainWorktreePath string) bool {
 	// But if it does, we can't determine without more context
 	return false
 }
+
+// WorktreeGitDir returns the administrative git directory of the worktree at path.
+// Linked worktrees have a .git file pointing at $GIT_COMMON_DIR/worktrees/<id>,
+// while the main worktree has a .git directory.
+func WorktreeGitDir(path string) (string, error) {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return dotGit, nil
+	}
+
+	// #nosec G304 -- dotGit is the .git file of a worktree listed by git
+	content, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", err
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("unexpected .git file format in %s", path)
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	return filepath.Clean(gitDir), nil
+}
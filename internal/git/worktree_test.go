@@ -1,6 +1,10 @@
 package git
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestWorktreeName(t *testing.T) {
 	tests := []struct {
@@ -234,3 +238,53 @@ func TestWorktree_IsMainWorktree(t *testing.T) {
 		})
 	}
 }
+
+func TestWorktreeGitDir(t *testing.T) {
+	tempDir := t.TempDir()
+	mainPath := filepath.Join(tempDir, "repo")
+	linkedPath := filepath.Join(tempDir, "worktrees", "feature")
+	relativePath := filepath.Join(tempDir, "worktrees", "relative")
+	for _, dir := range []string{filepath.Join(mainPath, ".git"), linkedPath, relativePath} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	adminDir := filepath.Join(mainPath, ".git", "worktrees", "feature")
+	if err := os.WriteFile(filepath.Join(linkedPath, ".git"), []byte("gitdir: "+adminDir+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	relativeGitFile := "gitdir: ../../repo/.git/worktrees/relative\n"
+	if err := os.WriteFile(filepath.Join(relativePath, ".git"), []byte(relativeGitFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "main worktree", path: mainPath, expected: filepath.Join(mainPath, ".git")},
+		{name: "linked worktree", path: linkedPath, expected: adminDir},
+		{
+			name:     "relative gitdir",
+			path:     relativePath,
+			expected: filepath.Join(mainPath, ".git", "worktrees", "relative"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WorktreeGitDir(tt.path)
+			if err != nil {
+				t.Fatalf("WorktreeGitDir() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("WorktreeGitDir() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := WorktreeGitDir(filepath.Join(tempDir, "missing")); err == nil {
+		t.Error("WorktreeGitDir() expected error for a path without .git")
+	}
+}
@@ -0,0 +1,38 @@
+package hooks
+
+import (
+	"strings"
+)
+
+// parseDotenv parses KEY=value lines as found in .env files. Blank lines and
+// comments are skipped, an "export " prefix is allowed, and matching single or
+// double quotes around a value are removed. Later keys override earlier ones.
+func parseDotenv(content string) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		values[key] = unquoteDotenvValue(strings.TrimSpace(value))
+	}
+	return values
+}
+
+func unquoteDotenvValue(value string) string {
+	const minQuoted = 2
+	if len(value) >= minQuoted {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,94 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+// baseEnvNames are the variables that clean and allowlist hooks still inherit:
+// without them programs cannot be found or write temporary files.
+var baseEnvNames = []string{
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "TMPDIR", "TERM", "LANG", "LC_ALL",
+	// Windows
+	"SYSTEMROOT", "WINDIR", "COMSPEC", "PATHEXT", "TEMP", "TMP", "USERPROFILE",
+}
+
+// inheritedEnv returns the part of wtp's environment a command hook inherits under
+// its env_mode. WTP_SHELL_INTEGRATION is never inherited.
+func inheritedEnv(hook *config.Hook) []string {
+	env := os.Environ()
+	inherited := make([]string, 0, len(env))
+	for _, entry := range env {
+		name, _, _ := strings.Cut(entry, "=")
+		if name == "WTP_SHELL_INTEGRATION" {
+			continue
+		}
+		if hook.EnvMode == config.EnvModeClean || hook.EnvMode == config.EnvModeAllowlist {
+			if !matchesEnvName(name, baseEnvNames) && !matchesEnvName(name, hook.EnvAllow) {
+				continue
+			}
+		}
+		inherited = append(inherited, entry)
+	}
+	return inherited
+}
+
+// matchesEnvName reports whether name matches one of the glob patterns.
+// Variable names are case-insensitive on Windows.
+func matchesEnvName(name string, patterns []string) bool {
+	if runtime.GOOS == windowsOS {
+		name = strings.ToUpper(name)
+	}
+	for _, pattern := range patterns {
+		if runtime.GOOS == windowsOS {
+			pattern = strings.ToUpper(pattern)
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// envFileEnv reads the env_file of a command hook from the main worktree as sorted
+// KEY=value entries. A missing file is an error: the hook would silently run
+// with a different environment otherwise.
+func (e *Executor) envFileEnv(hook *config.Hook) ([]string, error) {
+	if hook.EnvFile == "" {
+		return nil, nil
+	}
+
+	file := hook.EnvFile
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(e.repoRoot, file)
+		if err := ensureWithinBase(e.repoRoot, file); err != nil {
+			return nil, err
+		}
+	}
+
+	// #nosec G304 -- env files come from the project configuration
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env_file: %w", err)
+	}
+
+	values := parseDotenv(string(content))
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, key+"="+values[key])
+	}
+	return env, nil
+}
@@ -0,0 +1,93 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func TestExecutePostCreateHooks_EnvMode(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Skipping command test on Windows")
+	}
+	t.Setenv("NODE_ENV", "production")
+	t.Setenv("VIRTUAL_ENV", "/tmp/venv")
+
+	report := `echo "node=[$NODE_ENV] venv=[$VIRTUAL_ENV] home=[$HOME] db=[$DB_NAME]"`
+	tests := []struct {
+		name     string
+		hook     config.Hook
+		expected string
+	}{
+		{
+			name:     "inherit passes the whole environment",
+			hook:     config.Hook{Type: config.HookTypeCommand, Command: report},
+			expected: "node=[production] venv=[/tmp/venv] home=[" + os.Getenv("HOME") + "] db=[]",
+		},
+		{
+			name:     "clean keeps only the base variables",
+			hook:     config.Hook{Type: config.HookTypeCommand, Command: report, EnvMode: config.EnvModeClean},
+			expected: "node=[] venv=[] home=[" + os.Getenv("HOME") + "] db=[]",
+		},
+		{
+			name: "allowlist adds matching variables",
+			hook: config.Hook{
+				Type:     config.HookTypeCommand,
+				Command:  report,
+				EnvMode:  config.EnvModeAllowlist,
+				EnvAllow: []string{"NODE_*"},
+			},
+			expected: "node=[production] venv=[] home=[" + os.Getenv("HOME") + "] db=[]",
+		},
+		{
+			name: "env_file is loaded from the main worktree",
+			hook: config.Hook{
+				Type:    config.HookTypeCommand,
+				Command: report,
+				EnvMode: config.EnvModeClean,
+				EnvFile: ".env.hooks",
+				Env:     map[string]string{"NODE_ENV": "test"},
+			},
+			expected: "node=[test] venv=[] home=[" + os.Getenv("HOME") + "] db=[app_dev]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoRoot := t.TempDir()
+			content := "DB_NAME=app_dev\nNODE_ENV=development\n"
+			require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".env.hooks"), []byte(content), 0o600))
+			cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{tt.hook}}}
+
+			var buf bytes.Buffer
+			require.NoError(t, NewExecutor(cfg, repoRoot).ExecutePostCreateHooks(&buf, t.TempDir()))
+			assert.Contains(t, buf.String(), tt.expected)
+		})
+	}
+}
+
+func TestExecutePostCreateHooks_MissingEnvFile(t *testing.T) {
+	cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+		{Type: config.HookTypeCommand, Command: "echo unreachable", EnvFile: ".env.missing"},
+	}}}
+
+	var buf bytes.Buffer
+	err := NewExecutor(cfg, t.TempDir()).ExecutePostCreateHooks(&buf, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read env_file")
+	assert.NotContains(t, buf.String(), "unreachable")
+}
+
+func TestMatchesEnvName(t *testing.T) {
+	assert.True(t, matchesEnvName("NODE_ENV", []string{"NODE_*"}))
+	assert.True(t, matchesEnvName("CI", []string{"NODE_*", "CI"}))
+	assert.False(t, matchesEnvName("NODE_ENV", []string{"NODE"}))
+	assert.False(t, matchesEnvName("PATH", nil))
+}
@@ -0,0 +1,45 @@
+package hooks
+
+import (
+	"bytes"
+
+	"github.com/satococoa/wtp/v2/internal/events"
+)
+
+// outputEvents turns hook output into hook_output events, one per line.
+type outputEvents struct {
+	emitter *events.Emitter
+	event   events.Event
+	pending []byte
+}
+
+func newOutputEvents(emitter *events.Emitter, event events.Event) *outputEvents {
+	event.Type = events.HookOutput
+	return &outputEvents{emitter: emitter, event: event}
+}
+
+func (o *outputEvents) Write(p []byte) (int, error) {
+	o.pending = append(o.pending, p...)
+	for {
+		newline := bytes.IndexByte(o.pending, '\n')
+		if newline < 0 {
+			return len(p), nil
+		}
+		o.emit(o.pending[:newline])
+		o.pending = o.pending[newline+1:]
+	}
+}
+
+// flush emits a trailing line that was not terminated by a newline.
+func (o *outputEvents) flush() {
+	if len(o.pending) > 0 {
+		o.emit(o.pending)
+		o.pending = nil
+	}
+}
+
+func (o *outputEvents) emit(line []byte) {
+	event := o.event
+	event.Line = string(bytes.TrimSuffix(line, []byte("\r")))
+	o.emitter.Emit(event)
+}
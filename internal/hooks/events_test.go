@@ -0,0 +1,59 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/events"
+)
+
+func TestExecutePostCreateHooks_EmitsEvents(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("uses POSIX shell commands")
+	}
+
+	tempDir := t.TempDir()
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeCommand, Command: "printf 'one\\ntwo'; exit 4"},
+			},
+		},
+	}
+
+	var out, stream bytes.Buffer
+	executor := NewExecutorWithOptions(cfg, tempDir, Options{Events: events.NewEmitter(&stream)})
+	require.Error(t, executor.ExecutePostCreateHooks(&out, tempDir))
+
+	var received []events.Event
+	for _, line := range strings.Split(strings.TrimSpace(stream.String()), "\n") {
+		var event events.Event
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		received = append(received, event)
+	}
+
+	require.NotEmpty(t, received)
+	assert.Equal(t, events.HookStarted, received[0].Type)
+	assert.Equal(t, 1, received[0].HookTotal)
+	assert.Equal(t, config.HookTypeCommand, received[0].HookType)
+
+	var lines []string
+	for _, event := range received[1 : len(received)-1] {
+		assert.Equal(t, events.HookOutput, event.Type)
+		lines = append(lines, event.Line)
+	}
+	assert.Equal(t, []string{"  Running: printf 'one\\ntwo'; exit 4", "one", "two"}, lines)
+
+	finished := received[len(received)-1]
+	assert.Equal(t, events.HookFinished, finished.Type)
+	require.NotNil(t, finished.ExitCode)
+	assert.Equal(t, 4, *finished.ExitCode)
+	assert.NotNil(t, finished.DurationMS)
+}
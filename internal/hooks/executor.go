@@ -7,29 +7,78 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"time"
+
+	"golang.org/x/term"
 
 	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/events"
 )
 
 const (
 	directoryPermissions = 0o755
 	windowsOS            = "windows"
+	// hookVariableCount is the number of GIT_WTP_* variables set for every command hook.
+	hookVariableCount = 10
 )
 
+// EventPostCreate is the GIT_WTP_EVENT value of hooks run after a worktree is created.
+const EventPostCreate = "post_create"
+
 // Executor handles hook execution
 type Executor struct {
 	config   *config.Config
 	repoRoot string
+	opts     Options
+	// exported holds the variables written to GIT_WTP_ENV by hooks that have run.
+	exported map[string]string
+	// timings records the hooks of the last ExecutePostCreateHooks call.
+	timings []HookTiming
+}
+
+// Options customizes which hooks an Executor runs and how.
+type Options struct {
+	// Filter reports whether the hook at the given zero-based index should run.
+	// A nil Filter runs every hook.
+	Filter func(index int, hook *config.Hook) bool
+	// Log, when set, receives a timestamped copy of every hook run (see OpenLog).
+	Log io.Writer
+	// Events, when set, receives hook_started, hook_output and hook_finished events.
+	Events *events.Emitter
+	// Target describes the worktree the hooks run for; command hooks see it as GIT_WTP_* variables.
+	Target Target
+}
+
+// Target describes the worktree hooks run for, as far as the caller knows it.
+type Target struct {
+	// Name is the worktree name shown by wtp; it defaults to the directory name.
+	Name string
+	// Branch is the branch checked out in the worktree, empty when detached.
+	Branch string
+	// BaseRef is the commit-ish the worktree was created from.
+	BaseRef string
+	// Head is the commit checked out in the worktree.
+	Head string
+	// IsNewBranch reports whether the branch was created together with the worktree.
+	IsNewBranch bool
+	// Remote is the remote whose branch is tracked, when the worktree was created from one.
+	Remote string
 }
 
 // NewExecutor creates a new hook executor
 func NewExecutor(cfg *config.Config, repoRoot string) *Executor {
+	return NewExecutorWithOptions(cfg, repoRoot, Options{})
+}
+
+// NewExecutorWithOptions creates a new hook executor with the given options
+func NewExecutorWithOptions(cfg *config.Config, repoRoot string, opts Options) *Executor {
 	return &Executor{
 		config:   cfg,
 		repoRoot: repoRoot,
+		opts:     opts,
 	}
 }
 
@@ -39,16 +88,26 @@ func (e *Executor) ExecutePostCreateHooks(w io.Writer, worktreePath string) erro
 		return nil
 	}
 
+	e.timings = nil
 	totalHooks := len(e.config.Hooks.PostCreate)
 	for i, hook := range e.config.Hooks.PostCreate {
+		if !e.shouldRun(i, &hook) {
+			e.recordTiming(i, &hook, worktreePath, TimingSkipped, 0)
+			continue
+		}
+
 		// Log which hook is starting
-		if _, err := fmt.Fprintf(w, "\n→ Running hook %d of %d...\n", i+1, totalHooks); err != nil {
+		if _, err := fmt.Fprintf(w, "\n→ Running hook %d of %d%s...\n", i+1, totalHooks, hookLabel(&hook)); err != nil {
 			return err
 		}
 
-		if err := e.executeHookWithWriter(w, &hook, worktreePath); err != nil {
+		started := time.Now()
+		if err := e.executeRecordedHook(w, i, &hook, worktreePath); err != nil {
+			e.recordTiming(i, &hook, worktreePath, TimingFailed, time.Since(started))
+			e.recordRemaining(i+1, worktreePath)
 			return fmt.Errorf("failed to execute hook %d: %w", i+1, err)
 		}
+		e.recordTiming(i, &hook, worktreePath, TimingOK, time.Since(started))
 
 		// Log successful completion
 		if _, err := fmt.Fprintf(w, "✓ Hook %d completed\n", i+1); err != nil {
@@ -59,13 +118,70 @@ func (e *Executor) ExecutePostCreateHooks(w io.Writer, worktreePath string) erro
 	return nil
 }
 
+// hookLabel returns " (<name>)" for hooks with a name or id, for progress lines.
+func hookLabel(hook *config.Hook) string {
+	switch {
+	case hook.Name != "":
+		return " (" + hook.Name + ")"
+	case hook.ID != "":
+		return " (" + hook.ID + ")"
+	default:
+		return ""
+	}
+}
+
+func (e *Executor) shouldRun(index int, hook *config.Hook) bool {
+	return e.opts.Filter == nil || e.opts.Filter(index, hook)
+}
+
+// executeRecordedHook executes a single hook, copying its output to the hook log
+// and event stream when they are configured.
+func (e *Executor) executeRecordedHook(w io.Writer, index int, hook *config.Hook, worktreePath string) error {
+	if e.opts.Log == nil && e.opts.Events == nil {
+		return e.executeHookWithWriter(w, index, hook, worktreePath)
+	}
+
+	started := time.Now()
+	event := events.Event{
+		Worktree:  worktreePath,
+		Hook:      index + 1,
+		HookTotal: len(e.config.Hooks.PostCreate),
+		HookType:  hook.Type,
+	}
+	writers := []io.Writer{w}
+
+	log := logWriter{w: e.opts.Log}
+	if e.opts.Log != nil {
+		log.start(started, index, event.HookTotal, hook, worktreePath)
+		writers = append(writers, log)
+	}
+
+	output := newOutputEvents(e.opts.Events, event)
+	if e.opts.Events != nil {
+		startedEvent := event
+		startedEvent.Type = events.HookStarted
+		e.opts.Events.Emit(startedEvent)
+		writers = append(writers, output)
+	}
+
+	err := e.executeHookWithWriter(io.MultiWriter(writers...), index, hook, worktreePath)
+
+	output.flush()
+	if e.opts.Log != nil {
+		log.finish(index, started, err)
+	}
+	event.Type = events.HookFinished
+	e.opts.Events.Emit(events.Finished(event, started, err))
+	return err
+}
+
 // executeHookWithWriter executes a single hook with output directed to writer
-func (e *Executor) executeHookWithWriter(w io.Writer, hook *config.Hook, worktreePath string) error {
+func (e *Executor) executeHookWithWriter(w io.Writer, index int, hook *config.Hook, worktreePath string) error {
 	switch hook.Type {
 	case config.HookTypeCopy:
 		return e.executeCopyHookWithWriter(w, hook, worktreePath)
 	case config.HookTypeCommand:
-		return e.executeCommandHookWithWriter(w, hook, worktreePath)
+		return e.executeCommandHookWithWriter(w, index, hook, worktreePath)
 	case config.HookTypeSymlink:
 		return e.executeSymlinkHookWithWriter(w, hook, worktreePath)
 	default:
@@ -75,28 +191,9 @@ func (e *Executor) executeHookWithWriter(w io.Writer, hook *config.Hook, worktre
 
 // executeCopyHookWithWriter executes a copy hook with output directed to writer
 func (e *Executor) executeCopyHookWithWriter(w io.Writer, hook *config.Hook, worktreePath string) error {
-	// Resolve source path (relative to repo root)
-	srcPath := hook.From
-	if !filepath.IsAbs(srcPath) {
-		srcPath = filepath.Join(e.repoRoot, srcPath)
-	}
-	srcPath = filepath.Clean(srcPath)
-	if !filepath.IsAbs(hook.From) {
-		if err := ensureWithinBase(e.repoRoot, srcPath); err != nil {
-			return err
-		}
-	}
-
-	// Resolve destination path (relative to worktree)
-	dstPath := hook.To
-	if !filepath.IsAbs(dstPath) {
-		dstPath = filepath.Join(worktreePath, dstPath)
-	}
-	dstPath = filepath.Clean(dstPath)
-	if !filepath.IsAbs(hook.To) {
-		if err := ensureWithinBase(worktreePath, dstPath); err != nil {
-			return err
-		}
+	srcPath, dstPath, err := e.resolveHookPaths(hook, worktreePath)
+	if err != nil {
+		return err
 	}
 
 	// Check if source exists
@@ -129,28 +226,9 @@ func (e *Executor) executeCopyHookWithWriter(w io.Writer, hook *config.Hook, wor
 
 // executeSymlinkHookWithWriter executes a symlink hook with output directed to writer
 func (e *Executor) executeSymlinkHookWithWriter(w io.Writer, hook *config.Hook, worktreePath string) error {
-	// Resolve source path (relative to repo root)
-	srcPath := hook.From
-	if !filepath.IsAbs(srcPath) {
-		srcPath = filepath.Join(e.repoRoot, srcPath)
-	}
-	srcPath = filepath.Clean(srcPath)
-	if !filepath.IsAbs(hook.From) {
-		if err := ensureWithinBase(e.repoRoot, srcPath); err != nil {
-			return err
-		}
-	}
-
-	// Resolve destination path (relative to worktree)
-	dstPath := hook.To
-	if !filepath.IsAbs(dstPath) {
-		dstPath = filepath.Join(worktreePath, dstPath)
-	}
-	dstPath = filepath.Clean(dstPath)
-	if !filepath.IsAbs(hook.To) {
-		if err := ensureWithinBase(worktreePath, dstPath); err != nil {
-			return err
-		}
+	srcPath, dstPath, err := e.resolveHookPaths(hook, worktreePath)
+	if err != nil {
+		return err
 	}
 
 	// Check if source exists
@@ -158,6 +236,18 @@ func (e *Executor) executeSymlinkHookWithWriter(w io.Writer, hook *config.Hook,
 	if err != nil {
 		return fmt.Errorf("source path does not exist: %s", srcPath)
 	}
+
+	relSrc, _ := filepath.Rel(e.repoRoot, srcPath)
+	relDst, _ := filepath.Rel(worktreePath, dstPath)
+	linked, err := clearSymlinkDestination(srcPath, dstPath, srcInfo)
+	if err != nil {
+		return err
+	}
+	if linked {
+		_, err := fmt.Fprintf(w, "  Already linked: %s → %s\n", relSrc, relDst)
+		return err
+	}
+
 	if err := ensureDistinctPaths(srcPath, dstPath, srcInfo); err != nil {
 		return err
 	}
@@ -176,20 +266,76 @@ func (e *Executor) executeSymlinkHookWithWriter(w io.Writer, hook *config.Hook,
 	}
 
 	// Log the symlink operation to writer
-	relSrc, _ := filepath.Rel(e.repoRoot, srcPath)
-	relDst, _ := filepath.Rel(worktreePath, dstPath)
 	if _, err := fmt.Fprintf(w, "  Symlinking: %s → %s\n", relSrc, relDst); err != nil {
 		return err
 	}
 
-	// Use absolute path to avoid ambiguity and match copy hook behavior.
-	if err := os.Symlink(srcPath, dstPath); err != nil {
+	// Use absolute path by default to avoid ambiguity and match copy hook behavior.
+	linkTarget, err := symlinkTarget(srcPath, dstPath, hook.Relative)
+	if err != nil {
+		return err
+	}
+	if err := os.Symlink(linkTarget, dstPath); err != nil {
 		return fmt.Errorf("failed to create symlink: %w", err)
 	}
 
 	return nil
 }
 
+// clearSymlinkDestination makes running a symlink hook again safe: it reports a link at
+// dstPath that already resolves to the source, and removes a link that points anywhere
+// else. Regular files and directories are left for the caller to refuse.
+func clearSymlinkDestination(srcPath, dstPath string, srcInfo os.FileInfo) (linked bool, err error) {
+	info, err := os.Lstat(dstPath)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 || srcPath == dstPath {
+		return false, nil
+	}
+	if linksTo(dstPath, srcInfo) {
+		return true, nil
+	}
+	if err := os.Remove(dstPath); err != nil {
+		return false, fmt.Errorf("failed to replace stale symlink %s: %w", dstPath, err)
+	}
+	return false, nil
+}
+
+// linksTo reports whether the symlink at linkPath resolves to the file described by srcInfo.
+func linksTo(linkPath string, srcInfo os.FileInfo) bool {
+	if srcInfo == nil {
+		return false
+	}
+	dstInfo, err := os.Stat(linkPath)
+	return err == nil && os.SameFile(srcInfo, dstInfo)
+}
+
+// resolveHookPaths resolves the source (relative to repo root) and destination
+// (relative to worktree) paths of a copy or symlink hook.
+func (e *Executor) resolveHookPaths(hook *config.Hook, worktreePath string) (srcPath, dstPath string, err error) {
+	srcPath = hook.From
+	if !filepath.IsAbs(srcPath) {
+		srcPath = filepath.Join(e.repoRoot, srcPath)
+	}
+	srcPath = filepath.Clean(srcPath)
+	if !filepath.IsAbs(hook.From) {
+		if err := ensureWithinBase(e.repoRoot, srcPath); err != nil {
+			return "", "", err
+		}
+	}
+
+	dstPath = hook.To
+	if !filepath.IsAbs(dstPath) {
+		dstPath = filepath.Join(worktreePath, dstPath)
+	}
+	dstPath = filepath.Clean(dstPath)
+	if !filepath.IsAbs(hook.To) {
+		if err := ensureWithinBase(worktreePath, dstPath); err != nil {
+			return "", "", err
+		}
+	}
+
+	return srcPath, dstPath, nil
+}
+
 func ensureWithinBase(base, target string) error {
 	rel, err := filepath.Rel(base, target)
 	if err != nil {
@@ -224,52 +370,66 @@ func ensureDistinctPaths(srcPath, dstPath string, srcInfo os.FileInfo) error {
 }
 
 // executeCommandHookWithWriter executes a command hook with output directed to writer
-func (e *Executor) executeCommandHookWithWriter(w io.Writer, hook *config.Hook, worktreePath string) error {
-	// Execute command using shell for unified command format
-	var cmd *exec.Cmd
-	if runtime.GOOS == windowsOS {
-		// #nosec G204 - Commands come from project configuration file controlled by developer
-		cmd = exec.Command("cmd", "/c", hook.Command)
-	} else {
-		// #nosec G204 - Commands come from project configuration file controlled by developer
-		cmd = exec.Command("sh", "-c", hook.Command)
+func (e *Executor) executeCommandHookWithWriter(w io.Writer, index int, hook *config.Hook, worktreePath string) error {
+	prog, err := e.hookProgram(hook)
+	if err != nil {
+		return err
+	}
+	cmd, cleanup, err := prog.command()
+	if err != nil {
+		return err
 	}
+	defer cleanup()
 
 	// Set working directory
-	workDir := hook.WorkDir
-	if workDir == "" {
-		workDir = worktreePath
-	} else if !filepath.IsAbs(workDir) {
-		workDir = filepath.Join(worktreePath, workDir)
-	}
-	cmd.Dir = workDir
-
-	// Set environment variables (filter out WTP_SHELL_INTEGRATION)
-	env := os.Environ()
-	filtered := make([]string, 0, len(env))
-	for _, e := range env {
-		if !strings.HasPrefix(e, "WTP_SHELL_INTEGRATION=") {
-			filtered = append(filtered, e)
-		}
+	cmd.Dir = commandWorkDir(hook, worktreePath)
+
+	envFile, removeEnvFile, err := newEnvFile()
+	if err != nil {
+		return err
 	}
-	cmd.Env = filtered
-	for key, value := range hook.Env {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+	defer removeEnvFile()
+	env, err := e.commandEnv(index, hook, worktreePath)
+	if err != nil {
+		return err
 	}
+	cmd.Env = append(env, envFileVariable+"="+envFile)
 
-	// Add worktree-specific environment variables
-	cmd.Env = append(cmd.Env,
-		fmt.Sprintf("GIT_WTP_WORKTREE_PATH=%s", worktreePath),
-		fmt.Sprintf("GIT_WTP_REPO_ROOT=%s", e.repoRoot))
+	secrets, err := e.hookSecrets(hook, cmd.Env)
+	if err != nil {
+		return err
+	}
+
+	if hook.Interactive {
+		if err := e.checkInteractive(); err != nil {
+			return err
+		}
+	}
 
 	// Log the command execution to writer
-	if _, err := fmt.Fprintf(w, "  Running: %s", hook.Command); err != nil {
+	if _, err := fmt.Fprintf(w, "  Running: %s", maskSecrets(commandDescription(hook), secrets)); err != nil {
 		return err
 	}
 	if _, err := fmt.Fprintln(w); err != nil {
 		return err
 	}
+	if err := applySandbox(w, cmd, hook, worktreePath); err != nil {
+		return err
+	}
 
+	if hook.Interactive {
+		err = runInteractive(cmd)
+	} else {
+		err = streamCommand(cmd, w, secrets)
+	}
+	if err != nil {
+		return err
+	}
+	return e.collectExports(envFile)
+}
+
+// streamCommand runs cmd, streaming its masked stdout and stderr to w.
+func streamCommand(cmd *exec.Cmd, w io.Writer, secrets []string) error {
 	// Create pipes for stdout and stderr to enable real-time streaming
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -292,15 +452,18 @@ func (e *Executor) executeCommandHookWithWriter(w io.Writer, hook *config.Hook,
 
 	synchronized := newSynchronizedWriter(w)
 
-	go func() {
-		_, err := io.Copy(synchronized, stdout)
-		done <- err
-	}()
-
-	go func() {
-		_, err := io.Copy(synchronized, stderr)
+	// Each stream is masked separately so that a secret split across writes
+	// cannot be interleaved with output from the other stream.
+	stream := func(r io.Reader) {
+		masked := newMaskingWriter(synchronized, secrets)
+		_, err := io.Copy(masked, r)
+		if flushErr := masked.flush(); err == nil {
+			err = flushErr
+		}
 		done <- err
-	}()
+	}
+	go stream(stdout)
+	go stream(stderr)
 
 	// Wait for streaming to complete
 	for i := 0; i < numStreams; i++ {
@@ -317,6 +480,106 @@ func (e *Executor) executeCommandHookWithWriter(w io.Writer, hook *config.Hook,
 	return nil
 }
 
+// terminalAvailable reports whether stdin and stderr are connected to a terminal.
+// Stdout is not required: the shell integration captures it for the worktree path.
+var terminalAvailable = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// stdoutIsTerminal reports whether stdout is connected to a terminal.
+var stdoutIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// checkInteractive fails fast when an interactive hook could not reach the user.
+func (e *Executor) checkInteractive() error {
+	if e.opts.Events != nil {
+		return fmt.Errorf("interactive hook cannot run while streaming events; run without --events")
+	}
+	if !terminalAvailable() {
+		return fmt.Errorf("interactive hook requires a terminal (stdin and stderr must be a TTY)")
+	}
+	return nil
+}
+
+// runInteractive runs cmd connected to the terminal. Its output bypasses the
+// hook log and masking, which is why interactive hooks cannot use 'mask'. When
+// stdout is captured, e.g. by the shell integration, the hook writes to stderr
+// instead so that the captured output stays clean.
+func runInteractive(cmd *exec.Cmd) error {
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	if !stdoutIsTerminal() {
+		cmd.Stdout = os.Stderr
+	}
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("command failed: %w", err)
+	}
+	return nil
+}
+
+// commandWorkDir resolves the working directory of a command hook.
+func commandWorkDir(hook *config.Hook, worktreePath string) string {
+	workDir := hook.WorkDir
+	if workDir == "" {
+		return worktreePath
+	}
+	if !filepath.IsAbs(workDir) {
+		return filepath.Join(worktreePath, workDir)
+	}
+	return workDir
+}
+
+// commandEnv returns the complete environment of a command hook: the environment
+// inherited under its env_mode, its env_file, the variables exported by earlier
+// hooks and hookEnv, which takes precedence.
+func (e *Executor) commandEnv(index int, hook *config.Hook, worktreePath string) ([]string, error) {
+	fileEnv, err := e.envFileEnv(hook)
+	if err != nil {
+		return nil, err
+	}
+	env := inheritedEnv(hook)
+	env = append(env, fileEnv...)
+	env = append(env, e.ExportedEnv()...)
+	return append(env, e.hookEnv(index, hook, worktreePath)...), nil
+}
+
+// hookEnv returns the variables wtp adds on top of the inherited environment of the
+// command hook at the given zero-based index. The GIT_WTP_* variables are always set,
+// empty when unknown, and form a stable contract for hook authors.
+func (e *Executor) hookEnv(index int, hook *config.Hook, worktreePath string) []string {
+	keys := make([]string, 0, len(hook.Env))
+	for key := range hook.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys)+hookVariableCount)
+	for _, key := range keys {
+		env = append(env, fmt.Sprintf("%s=%s", key, hook.Env[key]))
+	}
+
+	target := e.opts.Target
+	name := target.Name
+	if name == "" {
+		name = filepath.Base(worktreePath)
+	}
+
+	// Add worktree-specific environment variables
+	return append(env,
+		fmt.Sprintf("GIT_WTP_WORKTREE_PATH=%s", worktreePath),
+		fmt.Sprintf("GIT_WTP_REPO_ROOT=%s", e.repoRoot),
+		fmt.Sprintf("GIT_WTP_WORKTREE_NAME=%s", name),
+		fmt.Sprintf("GIT_WTP_BRANCH=%s", target.Branch),
+		fmt.Sprintf("GIT_WTP_BASE_REF=%s", target.BaseRef),
+		fmt.Sprintf("GIT_WTP_HEAD=%s", target.Head),
+		fmt.Sprintf("GIT_WTP_IS_NEW_BRANCH=%t", target.IsNewBranch),
+		fmt.Sprintf("GIT_WTP_REMOTE=%s", target.Remote),
+		fmt.Sprintf("GIT_WTP_HOOK_INDEX=%d", index+1),
+		fmt.Sprintf("GIT_WTP_EVENT=%s", EventPostCreate))
+}
+
 type synchronizedWriter struct {
 	mu sync.Mutex
 	w  io.Writer
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/events"
 )
 
 func TestExecutePostCreateHooks_NilConfig(t *testing.T) {
@@ -272,6 +273,68 @@ func TestExecutePostCreateHooks_Symlink_DestinationExists(t *testing.T) {
 	assert.Contains(t, err.Error(), "destination path already exists")
 }
 
+func TestExecutePostCreateHooks_Symlink_RunTwice(t *testing.T) {
+	requireSymlinkSupport(t)
+
+	tempDir := t.TempDir()
+	repoRoot := filepath.Join(tempDir, "repo")
+	worktreeDir := filepath.Join(tempDir, "worktree")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoRoot, ".bin"), directoryPermissions))
+	require.NoError(t, os.MkdirAll(worktreeDir, directoryPermissions))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".env"), []byte("A=1"), 0644))
+
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeSymlink, From: ".env", To: ".env"},
+				{Type: config.HookTypeCopy, From: ".env", To: ".env.copy"},
+				{Type: config.HookTypeSymlink, From: ".bin", To: "tools/.bin", Relative: true},
+			},
+		},
+	}
+
+	executor := NewExecutor(cfg, repoRoot)
+	require.NoError(t, executor.ExecutePostCreateHooks(&bytes.Buffer{}, worktreeDir))
+
+	var buf bytes.Buffer
+	err := executor.ExecutePostCreateHooks(&buf, worktreeDir)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Already linked: .env → .env")
+	assert.Contains(t, buf.String(), "Already linked: .bin → tools/.bin")
+	assert.Contains(t, buf.String(), "✓ Hook 3 completed")
+}
+
+func TestExecutePostCreateHooks_Symlink_ReplacesStaleSymlink(t *testing.T) {
+	requireSymlinkSupport(t)
+
+	tempDir := t.TempDir()
+	repoRoot := filepath.Join(tempDir, "repo")
+	worktreeDir := filepath.Join(tempDir, "worktree")
+	require.NoError(t, os.MkdirAll(repoRoot, directoryPermissions))
+	require.NoError(t, os.MkdirAll(worktreeDir, directoryPermissions))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".env"), []byte("A=1"), 0644))
+
+	dstPath := filepath.Join(worktreeDir, ".env")
+	require.NoError(t, os.Symlink(filepath.Join(tempDir, "old-repo", ".env"), dstPath))
+
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeSymlink, From: ".env", To: ".env"},
+			},
+		},
+	}
+
+	executor := NewExecutor(cfg, repoRoot)
+	var buf bytes.Buffer
+	require.NoError(t, executor.ExecutePostCreateHooks(&buf, worktreeDir))
+
+	linkTarget, err := os.Readlink(dstPath)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repoRoot, ".env"), linkTarget)
+	assert.Contains(t, buf.String(), "Symlinking: .env → .env")
+}
+
 func TestExecutePostCreateHooks_Symlink_PathTraversal(t *testing.T) {
 	tempDir := t.TempDir()
 	repoRoot := filepath.Join(tempDir, "repo")
@@ -538,6 +601,98 @@ func TestExecutePostCreateHooks_CommandFailure(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to execute hook")
 }
 
+func TestExecutePostCreateHooks_InteractiveCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping command test on Windows")
+	}
+
+	interactiveConfig := func() *config.Config {
+		return &config.Config{
+			Hooks: config.Hooks{
+				PostCreate: []config.Hook{
+					{
+						Type:        config.HookTypeCommand,
+						Command:     "touch prompted.txt",
+						Interactive: true,
+					},
+				},
+			},
+		}
+	}
+	useTerminal := func(t *testing.T, available bool) {
+		t.Helper()
+		original := terminalAvailable
+		terminalAvailable = func() bool { return available }
+		t.Cleanup(func() { terminalAvailable = original })
+	}
+
+	t.Run("runs connected to the terminal", func(t *testing.T) {
+		useTerminal(t, true)
+		worktreeDir := t.TempDir()
+
+		var buf bytes.Buffer
+		err := NewExecutor(interactiveConfig(), t.TempDir()).ExecutePostCreateHooks(&buf, worktreeDir)
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Running: touch prompted.txt")
+		assert.FileExists(t, filepath.Join(worktreeDir, "prompted.txt"))
+	})
+
+	t.Run("writes to stderr when stdout is captured", func(t *testing.T) {
+		useTerminal(t, true)
+		originalStdoutIsTerminal, originalStdout, originalStderr := stdoutIsTerminal, os.Stdout, os.Stderr
+		t.Cleanup(func() {
+			stdoutIsTerminal, os.Stdout, os.Stderr = originalStdoutIsTerminal, originalStdout, originalStderr
+		})
+		stdoutIsTerminal = func() bool { return false }
+		captured, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+		require.NoError(t, err)
+		defer captured.Close()
+		terminal, err := os.Create(filepath.Join(t.TempDir(), "stderr"))
+		require.NoError(t, err)
+		defer terminal.Close()
+		os.Stdout, os.Stderr = captured, terminal
+
+		cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+			{Type: config.HookTypeCommand, Command: "echo prompted", Interactive: true},
+		}}}
+		err = NewExecutor(cfg, t.TempDir()).ExecutePostCreateHooks(&bytes.Buffer{}, t.TempDir())
+		require.NoError(t, err)
+
+		stdout, err := os.ReadFile(captured.Name())
+		require.NoError(t, err)
+		stderr, err := os.ReadFile(terminal.Name())
+		require.NoError(t, err)
+		assert.Empty(t, string(stdout), "captured stdout only holds what wtp prints")
+		assert.Equal(t, "prompted\n", string(stderr))
+	})
+
+	t.Run("fails fast without a terminal", func(t *testing.T) {
+		useTerminal(t, false)
+		worktreeDir := t.TempDir()
+
+		var buf bytes.Buffer
+		err := NewExecutor(interactiveConfig(), t.TempDir()).ExecutePostCreateHooks(&buf, worktreeDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "interactive hook requires a terminal")
+		assert.NotContains(t, buf.String(), "Running:")
+		assert.NoFileExists(t, filepath.Join(worktreeDir, "prompted.txt"))
+	})
+
+	t.Run("fails fast while streaming events", func(t *testing.T) {
+		useTerminal(t, true)
+		worktreeDir := t.TempDir()
+
+		var buf, eventsBuf bytes.Buffer
+		executor := NewExecutorWithOptions(interactiveConfig(), t.TempDir(), Options{
+			Events: events.NewEmitter(&eventsBuf),
+		})
+		err := executor.ExecutePostCreateHooks(&buf, worktreeDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot run while streaming events")
+		assert.NoFileExists(t, filepath.Join(worktreeDir, "prompted.txt"))
+	})
+}
+
 func TestExecutePostCreateHooks_CopyNonExistentFile(t *testing.T) {
 	// Create temp directories
 	tempDir := t.TempDir()
@@ -711,6 +866,49 @@ func (sw *streamingWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+func TestExecutePostCreateHooks_TargetEnvironmentVariables(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping command test on Windows")
+	}
+
+	repoRoot := t.TempDir()
+	worktreeDir := filepath.Join(t.TempDir(), "feature-auth")
+	require.NoError(t, os.MkdirAll(worktreeDir, directoryPermissions))
+
+	printVars := "echo NAME=$GIT_WTP_WORKTREE_NAME BRANCH=$GIT_WTP_BRANCH BASE=$GIT_WTP_BASE_REF " +
+		"HEAD=$GIT_WTP_HEAD NEW=$GIT_WTP_IS_NEW_BRANCH REMOTE=$GIT_WTP_REMOTE " +
+		"INDEX=$GIT_WTP_HOOK_INDEX EVENT=$GIT_WTP_EVENT"
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeCommand, Command: "true"},
+				{Type: config.HookTypeCommand, Command: printVars},
+			},
+		},
+	}
+
+	t.Run("with target", func(t *testing.T) {
+		executor := NewExecutorWithOptions(cfg, repoRoot, Options{Target: Target{
+			Name:        "feature/auth",
+			Branch:      "feature/auth",
+			BaseRef:     "origin/feature/auth",
+			Head:        "0123abcd",
+			IsNewBranch: true,
+			Remote:      "origin",
+		}})
+		var buf bytes.Buffer
+		require.NoError(t, executor.ExecutePostCreateHooks(&buf, worktreeDir))
+		assert.Contains(t, buf.String(), "NAME=feature/auth BRANCH=feature/auth BASE=origin/feature/auth "+
+			"HEAD=0123abcd NEW=true REMOTE=origin INDEX=2 EVENT=post_create")
+	})
+
+	t.Run("without target", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewExecutor(cfg, repoRoot).ExecutePostCreateHooks(&buf, worktreeDir))
+		assert.Contains(t, buf.String(), "NAME=feature-auth BRANCH= BASE= HEAD= NEW=false REMOTE= INDEX=2 EVENT=post_create")
+	})
+}
+
 func TestExecutePostCreateHooks_StreamingOutput(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Skipping streaming test on Windows")
@@ -1348,3 +1546,9 @@ func TestExecutePostCreateHooks_CopyFileWithSpecialCharacters(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "special content", string(dstContent))
 }
+
+func TestHookLabel(t *testing.T) {
+	assert.Empty(t, hookLabel(&config.Hook{Type: config.HookTypeCopy}))
+	assert.Equal(t, " (deps)", hookLabel(&config.Hook{ID: "deps"}))
+	assert.Equal(t, " (Install dependencies)", hookLabel(&config.Hook{ID: "deps", Name: "Install dependencies"}))
+}
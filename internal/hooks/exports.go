@@ -0,0 +1,59 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// envFileVariable names the variable that points a command hook at its export file.
+const envFileVariable = "GIT_WTP_ENV"
+
+// newEnvFile creates the empty GIT_WTP_ENV file of a command hook. The
+// returned function removes it.
+func newEnvFile() (path string, cleanup func(), err error) {
+	file, err := os.CreateTemp("", "wtp-env-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create %s file: %w", envFileVariable, err)
+	}
+	path = file.Name()
+	if err := file.Close(); err != nil {
+		_ = os.Remove(path)
+		return "", nil, fmt.Errorf("failed to create %s file: %w", envFileVariable, err)
+	}
+	return path, func() { _ = os.Remove(path) }, nil
+}
+
+// collectExports reads the KEY=value lines a hook wrote to its GIT_WTP_ENV file.
+// Later hooks see them in their environment, and a later export of the same key wins.
+func (e *Executor) collectExports(path string) error {
+	// #nosec G304 -- path is the temporary file created by newEnvFile
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s file: %w", envFileVariable, err)
+	}
+
+	for key, value := range parseDotenv(string(content)) {
+		if e.exported == nil {
+			e.exported = make(map[string]string)
+		}
+		e.exported[key] = value
+	}
+	return nil
+}
+
+// ExportedEnv returns the variables hooks exported through GIT_WTP_ENV so far, as
+// sorted KEY=value entries. Callers pass them on to commands run after the hooks.
+func (e *Executor) ExportedEnv() []string {
+	keys := make([]string, 0, len(e.exported))
+	for key := range e.exported {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, key+"="+e.exported[key])
+	}
+	return env
+}
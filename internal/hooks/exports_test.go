@@ -0,0 +1,71 @@
+package hooks
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func TestExecutePostCreateHooks_ExportedEnv(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Skipping command test on Windows")
+	}
+
+	t.Run("later hooks see exported variables", func(t *testing.T) {
+		cfg := &config.Config{
+			Hooks: config.Hooks{
+				PostCreate: []config.Hook{
+					{Type: config.HookTypeCommand, Command: `echo "DB_NAME=app_feature" >> "$GIT_WTP_ENV"`},
+					{Type: config.HookTypeCommand, Command: `echo 'export PORT="5433"' >> "$GIT_WTP_ENV"`},
+					{Type: config.HookTypeCommand, Command: `echo "db=$DB_NAME port=$PORT"`},
+				},
+			},
+		}
+
+		executor := NewExecutor(cfg, t.TempDir())
+		var buf bytes.Buffer
+		require.NoError(t, executor.ExecutePostCreateHooks(&buf, t.TempDir()))
+		assert.Contains(t, buf.String(), "db=app_feature port=5433")
+		assert.Equal(t, []string{"DB_NAME=app_feature", "PORT=5433"}, executor.ExportedEnv())
+	})
+
+	t.Run("hook env takes precedence over exports", func(t *testing.T) {
+		cfg := &config.Config{
+			Hooks: config.Hooks{
+				PostCreate: []config.Hook{
+					{Type: config.HookTypeCommand, Command: `echo "MODE=exported" >> "$GIT_WTP_ENV"`},
+					{
+						Type:    config.HookTypeCommand,
+						Command: `echo "mode=$MODE"`,
+						Env:     map[string]string{"MODE": "configured"},
+					},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, NewExecutor(cfg, t.TempDir()).ExecutePostCreateHooks(&buf, t.TempDir()))
+		assert.Contains(t, buf.String(), "mode=configured")
+	})
+
+	t.Run("failed hooks do not export", func(t *testing.T) {
+		cfg := &config.Config{
+			Hooks: config.Hooks{
+				PostCreate: []config.Hook{
+					{Type: config.HookTypeCommand, Command: `echo "KEPT=1" >> "$GIT_WTP_ENV"`},
+					{Type: config.HookTypeCommand, Command: `echo "DROPPED=1" >> "$GIT_WTP_ENV"; exit 1`},
+				},
+			},
+		}
+
+		executor := NewExecutor(cfg, t.TempDir())
+		var buf bytes.Buffer
+		require.Error(t, executor.ExecutePostCreateHooks(&buf, t.TempDir()))
+		assert.Equal(t, []string{"KEPT=1"}, executor.ExportedEnv())
+	})
+}
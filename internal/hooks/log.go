@@ -0,0 +1,126 @@
+package hooks
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/events"
+)
+
+const (
+	logFilePermissions = 0o600
+	// maxLogSize is the size after which the hook log is rotated to LogPath + ".1".
+	maxLogSize = 1 << 20
+
+	logHeaderPrefix = "==> "
+	logFooterPrefix = "<== "
+)
+
+var logHeaderPattern = regexp.MustCompile(`^==> \S+ hook (\d+) of \d+`)
+
+// LogPath returns the hook log location inside a worktree's administrative git directory.
+func LogPath(gitDir string) string {
+	return filepath.Join(gitDir, "wtp", "hooks.log")
+}
+
+// OpenLog opens the hook log at path for appending, creating its directory and
+// rotating the previous log once it grows past maxLogSize.
+func OpenLog(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), directoryPermissions); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > maxLogSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return nil, fmt.Errorf("failed to rotate hook log: %w", err)
+		}
+	}
+
+	// #nosec G304 -- path is derived from the worktree's git directory
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, logFilePermissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open hook log: %w", err)
+	}
+	return file, nil
+}
+
+// logWriter records hook runs without letting log failures interrupt the hooks themselves.
+type logWriter struct {
+	w io.Writer
+}
+
+func (l logWriter) Write(p []byte) (int, error) {
+	_, _ = l.w.Write(p)
+	return len(p), nil
+}
+
+func (l logWriter) start(started time.Time, index, total int, hook *config.Hook, worktreePath string) {
+	_, _ = fmt.Fprintf(l, "%s%s hook %d of %d (%s) in %s\n",
+		logHeaderPrefix, started.Format(time.RFC3339), index+1, total, hook.Type, worktreePath)
+}
+
+func (l logWriter) finish(index int, started time.Time, hookErr error) {
+	duration := time.Since(started).Round(time.Millisecond)
+	if hookErr == nil {
+		_, _ = fmt.Fprintf(l, "%shook %d exit=0 duration=%s\n", logFooterPrefix, index+1, duration)
+		return
+	}
+	_, _ = fmt.Fprintf(l, "%shook %d exit=%d duration=%s error=%v\n",
+		logFooterPrefix, index+1, events.ExitCode(hookErr), duration, hookErr)
+}
+
+// LogFilter is a writer that passes through only the log sections of one hook.
+type LogFilter struct {
+	w         io.Writer
+	hook      int
+	inSection bool
+	pending   []byte
+}
+
+// NewLogFilter returns a LogFilter writing the sections of the given 1-based hook
+// number to w. A hook number of zero passes the whole log through.
+func NewLogFilter(w io.Writer, hook int) *LogFilter {
+	return &LogFilter{w: w, hook: hook}
+}
+
+// Write buffers partial lines so that section markers are matched on whole lines.
+func (f *LogFilter) Write(p []byte) (int, error) {
+	if f.hook == 0 {
+		return f.w.Write(p)
+	}
+
+	f.pending = append(f.pending, p...)
+	for {
+		newline := bytes.IndexByte(f.pending, '\n')
+		if newline < 0 {
+			return len(p), nil
+		}
+		line := f.pending[:newline+1]
+		if err := f.writeLine(line); err != nil {
+			return 0, err
+		}
+		f.pending = f.pending[newline+1:]
+	}
+}
+
+func (f *LogFilter) writeLine(line []byte) error {
+	if match := logHeaderPattern.FindSubmatch(line); match != nil {
+		number, _ := strconv.Atoi(string(match[1]))
+		f.inSection = number == f.hook
+	}
+	if !f.inSection {
+		return nil
+	}
+	if bytes.HasPrefix(line, []byte(logFooterPrefix)) {
+		f.inSection = false
+	}
+	_, err := f.w.Write(line)
+	return err
+}
@@ -0,0 +1,88 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func TestExecutePostCreateHooks_WritesLog(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("uses POSIX shell commands")
+	}
+
+	tempDir := t.TempDir()
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeCommand, Command: "echo first"},
+				{Type: config.HookTypeCommand, Command: "echo second; exit 3"},
+			},
+		},
+	}
+
+	var out, log bytes.Buffer
+	executor := NewExecutorWithOptions(cfg, tempDir, Options{Log: &log})
+	err := executor.ExecutePostCreateHooks(&out, tempDir)
+	require.Error(t, err)
+
+	assert.Contains(t, out.String(), "second")
+	assert.Regexp(t, regexp.MustCompile(`(?m)^==> \S+ hook 1 of 2 \(command\) in `), log.String())
+	assert.Regexp(t, regexp.MustCompile(`(?m)^<== hook 1 exit=0 duration=\S+$`), log.String())
+	assert.Regexp(t, regexp.MustCompile(`(?m)^<== hook 2 exit=3 duration=\S+ error=command failed`), log.String())
+	assert.Contains(t, log.String(), "first\n")
+	assert.NotContains(t, log.String(), "Running hook", "progress lines belong to the terminal only")
+}
+
+func TestLogFilter(t *testing.T) {
+	logContent := "==> 2026-01-02T03:04:05Z hook 1 of 2 (copy) in /wt\n" +
+		"  Copying: .env → .env\n" +
+		"<== hook 1 exit=0 duration=1ms\n" +
+		"==> 2026-01-02T03:04:05Z hook 2 of 2 (command) in /wt\n" +
+		"  Running: make\n" +
+		"<== hook 2 exit=2 duration=5ms error=command failed: exit status 2\n"
+
+	t.Run("zero keeps everything", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := NewLogFilter(&buf, 0).Write([]byte(logContent))
+		require.NoError(t, err)
+		assert.Equal(t, logContent, buf.String())
+	})
+
+	t.Run("selects one hook across split writes", func(t *testing.T) {
+		var buf bytes.Buffer
+		filter := NewLogFilter(&buf, 2)
+		for i := 0; i < len(logContent); i += 7 {
+			_, err := filter.Write([]byte(logContent[i:min(i+7, len(logContent))]))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, "==> 2026-01-02T03:04:05Z hook 2 of 2 (command) in /wt\n"+
+			"  Running: make\n"+
+			"<== hook 2 exit=2 duration=5ms error=command failed: exit status 2\n", buf.String())
+	})
+}
+
+func TestOpenLog_Rotates(t *testing.T) {
+	path := LogPath(t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), directoryPermissions))
+	require.NoError(t, os.WriteFile(path, bytes.Repeat([]byte("x"), maxLogSize+1), logFilePermissions))
+
+	file, err := OpenLog(path)
+	require.NoError(t, err)
+	_, err = file.WriteString("fresh\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fresh\n", string(content))
+	assert.FileExists(t, path+".1")
+}
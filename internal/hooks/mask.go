@@ -0,0 +1,142 @@
+package hooks
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+const (
+	// maskReplacement is written in place of secret values.
+	maskReplacement = "***"
+	// minMaskLength is the shortest value that is masked; redacting shorter
+	// values would hide unrelated output.
+	minMaskLength = 4
+)
+
+// hookSecrets collects the values to redact from a command hook's output: the
+// values of the variables listed in mask (looked up in env) and every value
+// defined in the mask_files. Missing mask files are skipped.
+func (e *Executor) hookSecrets(hook *config.Hook, env []string) ([]string, error) {
+	if len(hook.Mask) == 0 && len(hook.MaskFiles) == 0 {
+		return nil, nil
+	}
+
+	envValues := make(map[string]string, len(env))
+	for _, entry := range env {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			envValues[key] = value
+		}
+	}
+
+	seen := make(map[string]bool)
+	var secrets []string
+	add := func(value string) {
+		if len(value) >= minMaskLength && !seen[value] {
+			seen[value] = true
+			secrets = append(secrets, value)
+		}
+	}
+
+	for _, name := range hook.Mask {
+		add(envValues[name])
+	}
+
+	for _, file := range hook.MaskFiles {
+		path := file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(e.repoRoot, path)
+			if err := ensureWithinBase(e.repoRoot, path); err != nil {
+				return nil, err
+			}
+		}
+
+		// #nosec G304 -- mask files come from the project configuration
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mask file: %w", err)
+		}
+		for _, value := range parseDotenv(string(content)) {
+			add(value)
+		}
+	}
+
+	// Longest first, so that a secret containing another is redacted as a whole.
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets, nil
+}
+
+// maskSecrets redacts every secret in s.
+func maskSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, maskReplacement)
+	}
+	return s
+}
+
+// maskingWriter redacts secrets before passing output on. Output that could be
+// the beginning of a secret split across writes is held back until the next
+// write or flush decides it.
+type maskingWriter struct {
+	w       io.Writer
+	secrets [][]byte
+	pending []byte
+}
+
+func newMaskingWriter(w io.Writer, secrets []string) *maskingWriter {
+	encoded := make([][]byte, len(secrets))
+	for i, secret := range secrets {
+		encoded[i] = []byte(secret)
+	}
+	return &maskingWriter{w: w, secrets: encoded}
+}
+
+func (m *maskingWriter) Write(p []byte) (int, error) {
+	m.pending = append(m.pending, p...)
+	for _, secret := range m.secrets {
+		m.pending = bytes.ReplaceAll(m.pending, secret, []byte(maskReplacement))
+	}
+
+	ready := len(m.pending) - m.partialSecretLength()
+	if ready > 0 {
+		if _, err := m.w.Write(m.pending[:ready]); err != nil {
+			return 0, err
+		}
+		m.pending = append(m.pending[:0], m.pending[ready:]...)
+	}
+	return len(p), nil
+}
+
+// partialSecretLength returns the length of the longest suffix of the pending
+// output that is a proper prefix of a secret.
+func (m *maskingWriter) partialSecretLength() int {
+	longest := 0
+	for _, secret := range m.secrets {
+		for n := min(len(secret)-1, len(m.pending)); n > longest; n-- {
+			if bytes.HasSuffix(m.pending, secret[:n]) {
+				longest = n
+				break
+			}
+		}
+	}
+	return longest
+}
+
+// flush writes output held back at the end of the stream.
+func (m *maskingWriter) flush() error {
+	if len(m.pending) == 0 {
+		return nil
+	}
+	_, err := m.w.Write(m.pending)
+	m.pending = nil
+	return err
+}
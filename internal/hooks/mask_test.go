@@ -0,0 +1,100 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func TestMaskingWriter(t *testing.T) {
+	secrets := []string{"s3cr3t-token", "hunter22"}
+
+	t.Run("masks complete secrets", func(t *testing.T) {
+		var buf bytes.Buffer
+		masked := newMaskingWriter(&buf, secrets)
+		_, err := masked.Write([]byte("token=s3cr3t-token pass=hunter22\n"))
+		require.NoError(t, err)
+		require.NoError(t, masked.flush())
+		assert.Equal(t, "token=*** pass=***\n", buf.String())
+	})
+
+	t.Run("masks secrets split across writes", func(t *testing.T) {
+		input := "before s3cr3t-token middle hunter22 after\n"
+		for chunk := 1; chunk <= len(input); chunk++ {
+			var buf bytes.Buffer
+			masked := newMaskingWriter(&buf, secrets)
+			for i := 0; i < len(input); i += chunk {
+				_, err := masked.Write([]byte(input[i:min(i+chunk, len(input))]))
+				require.NoError(t, err)
+				assert.NotContains(t, buf.String(), "s3cr3t", "chunk size %d", chunk)
+			}
+			require.NoError(t, masked.flush())
+			assert.Equal(t, "before *** middle *** after\n", buf.String(), "chunk size %d", chunk)
+		}
+	})
+
+	t.Run("holds back only possible secret prefixes", func(t *testing.T) {
+		var buf bytes.Buffer
+		masked := newMaskingWriter(&buf, secrets)
+		_, err := masked.Write([]byte("progress: s3cr"))
+		require.NoError(t, err)
+		assert.Equal(t, "progress: ", buf.String())
+
+		_, err = masked.Write([]byte("ets are fine"))
+		require.NoError(t, err)
+		require.NoError(t, masked.flush())
+		assert.Equal(t, "progress: s3crets are fine", buf.String())
+	})
+}
+
+func TestParseDotenv(t *testing.T) {
+	values := parseDotenv("# comment\n\nAPI_KEY=abc123\nexport TOKEN=\"quoted value\"\nSINGLE='x y'\ninvalid line\n")
+	assert.Equal(t, map[string]string{
+		"API_KEY": "abc123",
+		"TOKEN":   "quoted value",
+		"SINGLE":  "x y",
+	}, values)
+}
+
+func TestCommandHook_MasksSecrets(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("uses POSIX shell commands")
+	}
+
+	repoRoot := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".env.secrets"),
+		[]byte("DATABASE_PASSWORD=pa55w0rd-from-file\n"), 0o600))
+
+	cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{{
+		Type:      config.HookTypeCommand,
+		Command:   `echo "api=$API_TOKEN"; echo "db=pa55w0rd-from-file" >&2; echo "short=$SHORT"`,
+		Env:       map[string]string{"API_TOKEN": "tok-1234567890", "SHORT": "ab"},
+		Mask:      []string{"API_TOKEN", "SHORT"},
+		MaskFiles: []string{".env.secrets", ".env.missing"},
+	}}}}
+
+	var out, log bytes.Buffer
+	executor := NewExecutorWithOptions(cfg, repoRoot, Options{Log: &log})
+	require.NoError(t, executor.ExecutePostCreateHooks(&out, t.TempDir()))
+
+	for _, output := range []string{out.String(), log.String()} {
+		assert.Contains(t, output, "api=***")
+		assert.Contains(t, output, "db=***")
+		assert.Contains(t, output, "short=ab", "values shorter than the minimum are not masked")
+		assert.NotContains(t, output, "tok-1234567890")
+		assert.NotContains(t, output, "pa55w0rd")
+	}
+
+	var plan bytes.Buffer
+	require.NoError(t, executor.PlanPostCreateHooks(&plan, t.TempDir()))
+	assert.Contains(t, plan.String(), "API_TOKEN=***")
+	assert.Contains(t, plan.String(), "Masked:      API_TOKEN, SHORT, .env.secrets, .env.missing")
+	assert.NotContains(t, plan.String(), "tok-1234567890")
+}
@@ -0,0 +1,228 @@
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+// planLabelWidth aligns the values of plan lines after their labels.
+const planLabelWidth = 13
+
+// PlanPostCreateHooks resolves every post-create hook for worktreePath and writes
+// what would happen without touching the filesystem. It returns an error when at
+// least one selected hook is expected to fail.
+func (e *Executor) PlanPostCreateHooks(w io.Writer, worktreePath string) error {
+	if e.config == nil || !e.config.HasHooks() {
+		_, err := fmt.Fprintln(w, "No post-create hooks configured")
+		return err
+	}
+
+	totalHooks := len(e.config.Hooks.PostCreate)
+	failing := 0
+	for i := range e.config.Hooks.PostCreate {
+		hook := &e.config.Hooks.PostCreate[i]
+		if !e.shouldRun(i, hook) {
+			if _, err := fmt.Fprintf(w, "→ Hook %d of %d (%s): skipped, not selected\n",
+				i+1, totalHooks, hook.Type); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "→ Hook %d of %d (%s)\n", i+1, totalHooks, hook.Type); err != nil {
+			return err
+		}
+
+		problem, err := e.planHook(w, i, hook, worktreePath)
+		if err != nil {
+			return err
+		}
+		if problem != "" {
+			failing++
+			if _, err := fmt.Fprintf(w, "  ✗ Would fail: %s\n", problem); err != nil {
+				return err
+			}
+		}
+	}
+
+	if failing > 0 {
+		return fmt.Errorf("%d of %d hook(s) would fail", failing, totalHooks)
+	}
+	return nil
+}
+
+// planHook writes the resolved plan for a single hook and returns a description of
+// the problem that would make it fail, if any.
+func (e *Executor) planHook(w io.Writer, index int, hook *config.Hook, worktreePath string) (string, error) {
+	switch hook.Type {
+	case config.HookTypeCopy, config.HookTypeSymlink:
+		return e.planPathHook(w, hook, worktreePath)
+	case config.HookTypeCommand:
+		return e.planCommandHook(w, index, hook, worktreePath)
+	default:
+		return fmt.Sprintf("unknown hook type: %s", hook.Type), nil
+	}
+}
+
+func (e *Executor) planPathHook(w io.Writer, hook *config.Hook, worktreePath string) (string, error) {
+	srcPath, dstPath, err := e.resolveHookPaths(hook, worktreePath)
+	if err != nil {
+		return err.Error(), nil
+	}
+
+	srcState := "exists"
+	srcInfo, statErr := os.Stat(srcPath)
+	if statErr != nil {
+		srcState = "missing"
+	}
+	if err := writePlanLine(w, "Source:", fmt.Sprintf("%s (%s)", srcPath, srcState)); err != nil {
+		return "", err
+	}
+
+	dstState := planDestinationState(hook, srcPath, dstPath, srcInfo)
+	if err := writePlanLine(w, "Destination:", fmt.Sprintf("%s (%s)", dstPath, dstState)); err != nil {
+		return "", err
+	}
+
+	if hook.Type == config.HookTypeSymlink {
+		target, targetErr := symlinkTarget(srcPath, dstPath, hook.Relative)
+		if targetErr != nil {
+			return targetErr.Error(), nil
+		}
+		if err := writePlanLine(w, "Link target:", target); err != nil {
+			return "", err
+		}
+	}
+
+	if statErr != nil {
+		return fmt.Sprintf("source path does not exist: %s", srcPath), nil
+	}
+	if dstState == destinationLinked {
+		return "", nil
+	}
+	if err := ensureDistinctPaths(srcPath, dstPath, srcInfo); err != nil {
+		return err.Error(), nil
+	}
+	if hook.Type == config.HookTypeSymlink && dstState == destinationExists {
+		return fmt.Sprintf("destination path already exists: %s", dstPath), nil
+	}
+
+	return "", nil
+}
+
+const (
+	destinationExists = "exists"
+	destinationLinked = "already linked"
+)
+
+// planDestinationState describes what a copy or symlink hook would find at dstPath,
+// following the rules of executeSymlinkHookWithWriter for existing links.
+func planDestinationState(hook *config.Hook, srcPath, dstPath string, srcInfo os.FileInfo) string {
+	info, err := os.Lstat(dstPath)
+	switch {
+	case err != nil:
+		return "will be created"
+	case hook.Type == config.HookTypeCopy:
+		return "exists, will be overwritten"
+	case info.Mode()&os.ModeSymlink == 0 || srcPath == dstPath:
+		return destinationExists
+	case linksTo(dstPath, srcInfo):
+		return destinationLinked
+	default:
+		return "stale link, will be replaced"
+	}
+}
+
+func (e *Executor) planCommandHook(w io.Writer, index int, hook *config.Hook, worktreePath string) (string, error) {
+	env, envErr := e.commandEnv(index, hook, worktreePath)
+	secrets, secretsErr := e.hookSecrets(hook, env)
+	prog, progErr := e.hookProgram(hook)
+	if progErr == nil {
+		if err := writePlanLine(w, "Command:", maskSecrets(prog.display().String(), secrets)); err != nil {
+			return "", err
+		}
+	}
+	if err := writePlanLine(w, "Work dir:", commandWorkDir(hook, worktreePath)); err != nil {
+		return "", err
+	}
+
+	for i, entry := range e.hookEnv(index, hook, worktreePath) {
+		label := ""
+		if i == 0 {
+			label = "Env:"
+		}
+		if err := writePlanLine(w, label, maskSecrets(entry, secrets)); err != nil {
+			return "", err
+		}
+	}
+
+	if err := writeEnvModePlan(w, hook); err != nil {
+		return "", err
+	}
+	if err := writeSandboxPlan(w, hook); err != nil {
+		return "", err
+	}
+
+	if hook.Interactive {
+		if err := writePlanLine(w, "Interactive:", "yes, requires a terminal"); err != nil {
+			return "", err
+		}
+	}
+
+	if masked := append(append([]string{}, hook.Mask...), hook.MaskFiles...); len(masked) > 0 {
+		if err := writePlanLine(w, "Masked:", strings.Join(masked, ", ")); err != nil {
+			return "", err
+		}
+	}
+
+	if progErr != nil {
+		return progErr.Error(), nil
+	}
+	if envErr != nil {
+		return envErr.Error(), nil
+	}
+	if secretsErr != nil {
+		return secretsErr.Error(), nil
+	}
+	return "", nil
+}
+
+// writeEnvModePlan describes the inherited environment and env_file of a command hook
+// that does not simply inherit wtp's environment.
+func writeEnvModePlan(w io.Writer, hook *config.Hook) error {
+	switch hook.EnvMode {
+	case config.EnvModeClean:
+		if err := writePlanLine(w, "Env mode:", "clean, inherits only "+strings.Join(baseEnvNames, ", ")); err != nil {
+			return err
+		}
+	case config.EnvModeAllowlist:
+		if err := writePlanLine(w, "Env mode:", "allowlist, also inherits "+strings.Join(hook.EnvAllow, ", ")); err != nil {
+			return err
+		}
+	}
+	if hook.EnvFile != "" {
+		return writePlanLine(w, "Env file:", hook.EnvFile)
+	}
+	return nil
+}
+
+// writeSandboxPlan describes where a sandboxed command hook may write.
+func writeSandboxPlan(w io.Writer, hook *config.Hook) error {
+	if !hook.Sandbox {
+		return nil
+	}
+	if err := sandboxAvailable(); err != nil {
+		return writePlanLine(w, "Sandbox:", fmt.Sprintf("unavailable (%v), the hook would run without it", err))
+	}
+	writable := append([]string{"worktree", "temp dirs"}, hook.SandboxAllow...)
+	return writePlanLine(w, "Sandbox:", "writes limited to "+strings.Join(writable, ", "))
+}
+
+func writePlanLine(w io.Writer, label, value string) error {
+	_, err := fmt.Fprintf(w, "  %-*s%s\n", planLabelWidth, label, value)
+	return err
+}
@@ -0,0 +1,121 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func TestPlanPostCreateHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plan output uses POSIX shell commands")
+	}
+
+	tempDir := t.TempDir()
+	repoRoot := filepath.Join(tempDir, "repo")
+	worktreeDir := filepath.Join(tempDir, "worktree")
+	require.NoError(t, os.MkdirAll(repoRoot, directoryPermissions))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".env"), []byte("A=1"), 0644))
+
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeCopy, From: ".env", To: ".env"},
+				{Type: config.HookTypeSymlink, From: ".env", To: "link/.env", Relative: true},
+				{
+					Type:    config.HookTypeCommand,
+					Command: "npm install",
+					WorkDir: "web",
+					Env:     map[string]string{"NODE_ENV": "development"},
+				},
+				{Type: config.HookTypeCommand, Command: "gh auth login", Interactive: true},
+				{
+					Type:     config.HookTypeCommand,
+					Command:  "npm ci",
+					EnvMode:  config.EnvModeAllowlist,
+					EnvAllow: []string{"NODE_*"},
+					EnvFile:  ".env",
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := NewExecutor(cfg, repoRoot).PlanPostCreateHooks(&buf, worktreeDir)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "→ Hook 1 of 5 (copy)")
+	assert.Contains(t, output, "Source:      "+filepath.Join(repoRoot, ".env")+" (exists)")
+	assert.Contains(t, output, "Destination: "+filepath.Join(worktreeDir, ".env")+" (will be created)")
+	assert.Contains(t, output, "Link target: "+filepath.Join("..", "..", "repo", ".env"))
+	assert.Contains(t, output, `Command:     sh -c "npm install"`)
+	assert.Contains(t, output, "Work dir:    "+filepath.Join(worktreeDir, "web"))
+	assert.Contains(t, output, "Env:         NODE_ENV=development")
+	assert.Contains(t, output, "GIT_WTP_REPO_ROOT="+repoRoot)
+	assert.Contains(t, output, "Interactive: yes, requires a terminal")
+	assert.Contains(t, output, "Env mode:    allowlist, also inherits NODE_*")
+	assert.Contains(t, output, "Env file:    .env")
+
+	_, statErr := os.Stat(worktreeDir)
+	assert.True(t, os.IsNotExist(statErr), "plan must not create the worktree directory")
+}
+
+func TestPlanPostCreateHooks_ReportsFailures(t *testing.T) {
+	tempDir := t.TempDir()
+	repoRoot := filepath.Join(tempDir, "repo")
+	worktreeDir := filepath.Join(tempDir, "worktree")
+	require.NoError(t, os.MkdirAll(repoRoot, directoryPermissions))
+
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeCopy, From: ".env", To: ".env"},
+				{Type: config.HookTypeSymlink, From: "../outside", To: ".bin"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := NewExecutor(cfg, repoRoot).PlanPostCreateHooks(&buf, worktreeDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 2 hook(s) would fail")
+	assert.Contains(t, buf.String(), "Would fail: source path does not exist")
+	assert.Contains(t, buf.String(), "escapes base directory")
+}
+
+func TestPlanPostCreateHooks_ExistingSymlinks(t *testing.T) {
+	requireSymlinkSupport(t)
+
+	tempDir := t.TempDir()
+	repoRoot := filepath.Join(tempDir, "repo")
+	worktreeDir := filepath.Join(tempDir, "worktree")
+	require.NoError(t, os.MkdirAll(repoRoot, directoryPermissions))
+	require.NoError(t, os.MkdirAll(worktreeDir, directoryPermissions))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".env"), []byte("A=1"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".tool"), []byte("B=2"), 0644))
+	require.NoError(t, os.Symlink(filepath.Join(repoRoot, ".env"), filepath.Join(worktreeDir, ".env")))
+	require.NoError(t, os.Symlink(filepath.Join(tempDir, "gone"), filepath.Join(worktreeDir, ".tool")))
+
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeSymlink, From: ".env", To: ".env"},
+				{Type: config.HookTypeSymlink, From: ".tool", To: ".tool"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := NewExecutor(cfg, repoRoot).PlanPostCreateHooks(&buf, worktreeDir)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), filepath.Join(worktreeDir, ".env")+" (already linked)")
+	assert.Contains(t, buf.String(), filepath.Join(worktreeDir, ".tool")+" (stale link, will be replaced)")
+}
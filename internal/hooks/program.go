@@ -0,0 +1,150 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/satococoa/wtp/v2/internal/command"
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+// inlinePlaceholder stands in for the temporary script file in plan output.
+const inlinePlaceholder = "<inline script>"
+
+// program describes how a command hook is started.
+type program struct {
+	name string
+	args []string
+	// inline is code written to a temporary file whose path is appended to args at run time.
+	inline string
+}
+
+// hookProgram resolves the interpreter and arguments of a command hook.
+// Without a shell, command and run are passed to 'sh -c' ('cmd /c' on Windows)
+// and scripts are executed directly so that their shebang line is honored.
+// With a shell, inline code is written to a temporary file so that any
+// interpreter (bash, zsh, python3, node, ...) can run it.
+func (e *Executor) hookProgram(hook *config.Hook) (program, error) {
+	shell := strings.Fields(hook.Shell)
+
+	if hook.Script != "" {
+		scriptPath, err := e.resolveScriptPath(hook.Script, len(shell) == 0)
+		if err != nil {
+			return program{}, err
+		}
+		if len(shell) == 0 {
+			return program{name: scriptPath}, nil
+		}
+		return program{name: shell[0], args: append(shell[1:], scriptPath)}, nil
+	}
+
+	code := hook.Command
+	if code == "" {
+		code = hook.Run
+	}
+	if len(shell) == 0 {
+		if runtime.GOOS == windowsOS {
+			return program{name: "cmd", args: []string{"/c", code}}, nil
+		}
+		return program{name: "sh", args: []string{"-c", code}}, nil
+	}
+	return program{name: shell[0], args: shell[1:], inline: code}, nil
+}
+
+// resolveScriptPath resolves a script path from the main worktree and checks that it can be run.
+func (e *Executor) resolveScriptPath(script string, mustBeExecutable bool) (string, error) {
+	scriptPath := script
+	if !filepath.IsAbs(scriptPath) {
+		scriptPath = filepath.Join(e.repoRoot, scriptPath)
+		if err := ensureWithinBase(e.repoRoot, scriptPath); err != nil {
+			return "", err
+		}
+	}
+	scriptPath = filepath.Clean(scriptPath)
+
+	info, err := os.Stat(scriptPath)
+	if err != nil {
+		return "", fmt.Errorf("script does not exist: %s", scriptPath)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("script is a directory: %s", scriptPath)
+	}
+	if mustBeExecutable && runtime.GOOS != windowsOS && info.Mode().Perm()&0o111 == 0 {
+		return "", fmt.Errorf("script is not executable: %s (make it executable or set 'shell')", scriptPath)
+	}
+
+	return scriptPath, nil
+}
+
+// command builds the process for the program. The returned cleanup function
+// removes the temporary file created for inline code.
+func (p program) command() (cmd *exec.Cmd, cleanup func(), err error) {
+	cleanup = func() {}
+	args := p.args
+	if p.inline != "" {
+		scriptPath, writeErr := writeTempScript(p.inline, scriptExtension(p.name))
+		if writeErr != nil {
+			return nil, cleanup, writeErr
+		}
+		cleanup = func() { _ = os.Remove(scriptPath) }
+		args = append(append([]string{}, p.args...), scriptPath)
+	}
+
+	// #nosec G204 - Commands come from project configuration file controlled by developer
+	return exec.Command(p.name, args...), cleanup, nil
+}
+
+// display renders the program for plan output.
+func (p program) display() command.Command {
+	args := p.args
+	if p.inline != "" {
+		args = append(append([]string{}, p.args...), inlinePlaceholder)
+	}
+	return command.Command{Name: p.name, Args: args}
+}
+
+func writeTempScript(code, extension string) (string, error) {
+	file, err := os.CreateTemp("", "wtp-hook-*"+extension)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary script: %w", err)
+	}
+
+	_, writeErr := file.WriteString(code)
+	if err := errors.Join(writeErr, file.Close()); err != nil {
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write temporary script: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// scriptExtension returns the file extension an interpreter requires for script files.
+func scriptExtension(interpreter string) string {
+	switch strings.TrimSuffix(filepath.Base(interpreter), ".exe") {
+	case "pwsh", "powershell":
+		return ".ps1"
+	case "cmd":
+		return ".cmd"
+	default:
+		return ""
+	}
+}
+
+// commandDescription is the text shown when a command hook starts.
+func commandDescription(hook *config.Hook) string {
+	description := hook.Command
+	switch {
+	case hook.Script != "":
+		description = hook.Script
+	case hook.Run != "":
+		description = strings.TrimRight(hook.Run, "\n")
+	}
+	if hook.Shell != "" {
+		description += " (" + hook.Shell + ")"
+	}
+	return description
+}
@@ -0,0 +1,122 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func runCommandHook(t *testing.T, repoRoot string, hook config.Hook) (string, error) {
+	t.Helper()
+
+	worktreeDir := t.TempDir()
+	cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{hook}}}
+	var buf bytes.Buffer
+	err := NewExecutor(cfg, repoRoot).ExecutePostCreateHooks(&buf, worktreeDir)
+	return buf.String(), err
+}
+
+func TestCommandHook_Shell(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("uses POSIX interpreters")
+	}
+
+	t.Run("bash run block", func(t *testing.T) {
+		if _, err := exec.LookPath("bash"); err != nil {
+			t.Skip("bash not installed")
+		}
+
+		output, err := runCommandHook(t, t.TempDir(), config.Hook{
+			Type:  config.HookTypeCommand,
+			Shell: "bash",
+			Run:   "set -o pipefail\nitems=(alpha beta)\necho \"${items[1]}\" | cat\n",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output, "beta")
+	})
+
+	t.Run("python3 command", func(t *testing.T) {
+		if _, err := exec.LookPath("python3"); err != nil {
+			t.Skip("python3 not installed")
+		}
+
+		output, err := runCommandHook(t, t.TempDir(), config.Hook{
+			Type:    config.HookTypeCommand,
+			Shell:   "python3",
+			Command: "print(6 * 7)",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output, "42")
+	})
+
+	t.Run("shell arguments are preserved", func(t *testing.T) {
+		_, err := runCommandHook(t, t.TempDir(), config.Hook{
+			Type:  config.HookTypeCommand,
+			Shell: "sh -e",
+			Run:   "false\necho unreachable\n",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestCommandHook_Script(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("uses POSIX shell scripts")
+	}
+
+	repoRoot := t.TempDir()
+	scriptsDir := filepath.Join(repoRoot, "scripts")
+	require.NoError(t, os.MkdirAll(scriptsDir, directoryPermissions))
+	require.NoError(t, os.WriteFile(filepath.Join(scriptsDir, "setup.sh"),
+		[]byte("#!/bin/sh\necho \"setup in $(pwd)\"\n"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(scriptsDir, "plain.sh"), []byte("echo plain\n"), 0o644))
+
+	t.Run("executable script runs from the worktree", func(t *testing.T) {
+		output, err := runCommandHook(t, repoRoot, config.Hook{Type: config.HookTypeCommand, Script: "scripts/setup.sh"})
+		require.NoError(t, err)
+		assert.Contains(t, output, "Running: scripts/setup.sh")
+		assert.Contains(t, output, "setup in ")
+	})
+
+	t.Run("non-executable script needs a shell", func(t *testing.T) {
+		_, err := runCommandHook(t, repoRoot, config.Hook{Type: config.HookTypeCommand, Script: "scripts/plain.sh"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "script is not executable")
+
+		output, err := runCommandHook(t, repoRoot, config.Hook{
+			Type:   config.HookTypeCommand,
+			Script: "scripts/plain.sh",
+			Shell:  "sh",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output, "plain")
+	})
+
+	t.Run("script outside the repository is rejected", func(t *testing.T) {
+		_, err := runCommandHook(t, repoRoot, config.Hook{Type: config.HookTypeCommand, Script: "../setup.sh"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "escapes base directory")
+	})
+
+	t.Run("plan shows the interpreter", func(t *testing.T) {
+		cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+			{Type: config.HookTypeCommand, Script: "scripts/plain.sh", Shell: "bash"},
+			{Type: config.HookTypeCommand, Run: "echo hi\n", Shell: "zsh"},
+			{Type: config.HookTypeCommand, Script: "scripts/missing.sh"},
+		}}}
+		var buf bytes.Buffer
+		err := NewExecutor(cfg, repoRoot).PlanPostCreateHooks(&buf, t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, buf.String(), "Command:     bash "+filepath.Join(scriptsDir, "plain.sh"))
+		assert.Contains(t, buf.String(), `Command:     zsh "<inline script>"`)
+		assert.Contains(t, buf.String(), "Would fail: script does not exist")
+	})
+}
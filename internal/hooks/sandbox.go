@@ -0,0 +1,51 @@
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+	"github.com/satococoa/wtp/v2/internal/sandbox"
+)
+
+// sandboxAvailable reports whether sandboxed hooks can run; tests replace it.
+var sandboxAvailable = sandbox.Available
+
+// applySandbox runs cmd through the sandbox when the hook asks for it. Where the
+// platform lacks support, the hook runs unrestricted after a warning.
+func applySandbox(w io.Writer, cmd *exec.Cmd, hook *config.Hook, worktreePath string) error {
+	if !hook.Sandbox {
+		return nil
+	}
+	if err := sandboxAvailable(); err != nil {
+		_, writeErr := fmt.Fprintf(w, "  Warning: running hook without sandbox: %v\n", err)
+		return writeErr
+	}
+
+	policy, err := sandboxPolicy(hook, worktreePath)
+	if err != nil {
+		return err
+	}
+	return sandbox.Wrap(cmd, policy)
+}
+
+// sandboxPolicy allows a sandboxed hook to write to the worktree, the temporary
+// directory and the paths in sandbox_allow.
+func sandboxPolicy(hook *config.Hook, worktreePath string) (sandbox.Policy, error) {
+	policy := sandbox.Policy{Writable: []string{worktreePath, os.TempDir()}}
+	for _, path := range hook.SandboxAllow {
+		if path == "~" || strings.HasPrefix(path, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return sandbox.Policy{}, fmt.Errorf("failed to expand sandbox_allow path '%s': %w", path, err)
+			}
+			path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+		policy.Writable = append(policy.Writable, path)
+	}
+	return policy, nil
+}
@@ -0,0 +1,74 @@
+package hooks
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func stubSandboxAvailable(t *testing.T, err error) {
+	t.Helper()
+	original := sandboxAvailable
+	t.Cleanup(func() { sandboxAvailable = original })
+	sandboxAvailable = func() error { return err }
+}
+
+func TestExecutePostCreateHooks_SandboxFallback(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Skipping command test on Windows")
+	}
+	stubSandboxAvailable(t, errors.New("the kernel does not support Landlock"))
+
+	cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+		{Type: config.HookTypeCommand, Command: "echo still-ran", Sandbox: true},
+	}}}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewExecutor(cfg, t.TempDir()).ExecutePostCreateHooks(&buf, t.TempDir()))
+	assert.Contains(t, buf.String(), "Warning: running hook without sandbox: the kernel does not support Landlock")
+	assert.Contains(t, buf.String(), "still-ran")
+}
+
+func TestSandboxPolicy(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	hook := &config.Hook{
+		Type:         config.HookTypeCommand,
+		Command:      "npm ci",
+		Sandbox:      true,
+		SandboxAllow: []string{"~/.npm", "/opt/cache"},
+	}
+	policy, err := sandboxPolicy(hook, "/work/tree")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/work/tree", os.TempDir(), filepath.Join(home, ".npm"), "/opt/cache"}, policy.Writable)
+}
+
+func TestPlanPostCreateHooks_Sandbox(t *testing.T) {
+	cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+		{Type: config.HookTypeCommand, Command: "npm ci", Sandbox: true, SandboxAllow: []string{"~/.npm"}},
+	}}}
+
+	t.Run("available", func(t *testing.T) {
+		stubSandboxAvailable(t, nil)
+		var buf bytes.Buffer
+		require.NoError(t, NewExecutor(cfg, t.TempDir()).PlanPostCreateHooks(&buf, t.TempDir()))
+		assert.Contains(t, buf.String(), "Sandbox:     writes limited to worktree, temp dirs, ~/.npm")
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		stubSandboxAvailable(t, errors.New("sandboxed hooks require Linux with Landlock"))
+		var buf bytes.Buffer
+		require.NoError(t, NewExecutor(cfg, t.TempDir()).PlanPostCreateHooks(&buf, t.TempDir()))
+		assert.Contains(t, buf.String(), "Sandbox:     unavailable (sandboxed hooks require Linux with Landlock)")
+	})
+}
@@ -0,0 +1,164 @@
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+// DanglingSymlink describes a symlink created by a symlink hook whose target no longer exists.
+type DanglingSymlink struct {
+	HookIndex    int
+	WorktreePath string
+	LinkPath     string
+	Target       string
+}
+
+// symlinkTarget returns the target to store in the link at dstPath.
+// Relative targets are computed from the resolved parent directory of the link so
+// that the kernel resolves them the same way regardless of symlinked path components.
+func symlinkTarget(srcPath, dstPath string, relative bool) (string, error) {
+	if !relative {
+		return srcPath, nil
+	}
+
+	linkDir := filepath.Dir(dstPath)
+	if resolved, err := filepath.EvalSymlinks(linkDir); err == nil {
+		linkDir = resolved
+	}
+
+	srcDir := filepath.Dir(srcPath)
+	if resolved, err := filepath.EvalSymlinks(srcDir); err == nil {
+		srcDir = resolved
+	}
+
+	target, err := filepath.Rel(linkDir, filepath.Join(srcDir, filepath.Base(srcPath)))
+	if err != nil {
+		return "", fmt.Errorf("failed to compute relative symlink target: %w", err)
+	}
+
+	return target, nil
+}
+
+// FindDanglingSymlinks reports symlinks created by the configured symlink hooks in
+// worktreePath whose targets can no longer be resolved. Links that were never created
+// or have been replaced by regular files are ignored.
+func (e *Executor) FindDanglingSymlinks(worktreePath string) ([]DanglingSymlink, error) {
+	if e.config == nil {
+		return nil, nil
+	}
+
+	var dangling []DanglingSymlink
+	for i := range e.config.Hooks.PostCreate {
+		hook := &e.config.Hooks.PostCreate[i]
+		if hook.Type != config.HookTypeSymlink {
+			continue
+		}
+
+		_, dstPath, err := e.resolveHookPaths(hook, worktreePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid symlink hook %d: %w", i+1, err)
+		}
+
+		info, err := os.Lstat(dstPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to inspect %s: %w", dstPath, err)
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+
+		if _, err := os.Stat(dstPath); err == nil {
+			continue
+		}
+
+		target, err := os.Readlink(dstPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read symlink %s: %w", dstPath, err)
+		}
+
+		dangling = append(dangling, DanglingSymlink{
+			HookIndex:    i,
+			WorktreePath: worktreePath,
+			LinkPath:     dstPath,
+			Target:       target,
+		})
+	}
+
+	return dangling, nil
+}
+
+// RelinkSymlinks repairs the links created by the configured symlink hooks after
+// a worktree moved from oldPath to newPath. Links pointing into the old location
+// are pointed at the same file in the new one, and relative links are recomputed
+// for their new depth. Absolute links stay absolute and relative links relative.
+func (e *Executor) RelinkSymlinks(w io.Writer, oldPath, newPath string) error {
+	if e.config == nil {
+		return nil
+	}
+
+	for i := range e.config.Hooks.PostCreate {
+		hook := &e.config.Hooks.PostCreate[i]
+		if hook.Type != config.HookTypeSymlink || filepath.IsAbs(hook.To) {
+			continue
+		}
+
+		_, dstPath, err := e.resolveHookPaths(hook, newPath)
+		if err != nil {
+			return fmt.Errorf("invalid symlink hook %d: %w", i+1, err)
+		}
+		if err := relinkSymlink(w, dstPath, oldPath, newPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// relinkSymlink rewrites the link at dstPath, which lived below oldPath before it moved to newPath.
+func relinkSymlink(w io.Writer, dstPath, oldPath, newPath string) error {
+	info, err := os.Lstat(dstPath)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return nil
+	}
+
+	target, err := os.Readlink(dstPath)
+	if err != nil {
+		return fmt.Errorf("failed to read symlink %s: %w", dstPath, err)
+	}
+
+	relDst, err := filepath.Rel(newPath, dstPath)
+	if err != nil {
+		return nil
+	}
+	absTarget := target
+	if !filepath.IsAbs(target) {
+		absTarget = filepath.Join(oldPath, filepath.Dir(relDst), target)
+	}
+	if rel, err := filepath.Rel(oldPath, absTarget); err == nil && ensureWithinBase(oldPath, absTarget) == nil {
+		absTarget = filepath.Join(newPath, rel)
+	}
+
+	newTarget, err := symlinkTarget(absTarget, dstPath, !filepath.IsAbs(target))
+	if err != nil {
+		return err
+	}
+	if newTarget == target {
+		return nil
+	}
+
+	if err := os.Remove(dstPath); err != nil {
+		return fmt.Errorf("failed to replace symlink %s: %w", dstPath, err)
+	}
+	if err := os.Symlink(newTarget, dstPath); err != nil {
+		return fmt.Errorf("failed to create symlink: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "  Relinked: %s → %s\n", relDst, newTarget)
+	return err
+}
@@ -0,0 +1,165 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func TestExecutePostCreateHooks_SymlinkRelative(t *testing.T) {
+	requireSymlinkSupport(t)
+
+	tempDir := t.TempDir()
+	repoRoot := filepath.Join(tempDir, "repo")
+	worktreeDir := filepath.Join(tempDir, "worktrees", "feature")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repoRoot, ".bin"), directoryPermissions))
+	require.NoError(t, os.MkdirAll(worktreeDir, directoryPermissions))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".bin", "tool"), []byte("bin"), 0644))
+
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{
+					Type:     config.HookTypeSymlink,
+					From:     ".bin",
+					To:       "nested/.bin",
+					Relative: true,
+				},
+			},
+		},
+	}
+
+	executor := NewExecutor(cfg, repoRoot)
+	var buf bytes.Buffer
+	require.NoError(t, executor.ExecutePostCreateHooks(&buf, worktreeDir))
+
+	linkPath := filepath.Join(worktreeDir, "nested", ".bin")
+	linkTarget, err := os.Readlink(linkPath)
+	require.NoError(t, err)
+	assert.False(t, filepath.IsAbs(linkTarget))
+	assert.Equal(t, filepath.Join("..", "..", "..", "repo", ".bin"), linkTarget)
+
+	// Moving repo and worktrees together keeps the link valid.
+	movedDir := filepath.Join(t.TempDir(), "moved")
+	require.NoError(t, os.Rename(tempDir, movedDir))
+	content, err := os.ReadFile(filepath.Join(movedDir, "worktrees", "feature", "nested", ".bin", "tool"))
+	require.NoError(t, err)
+	assert.Equal(t, "bin", string(content))
+}
+
+func TestFindDanglingSymlinks(t *testing.T) {
+	requireSymlinkSupport(t)
+
+	tempDir := t.TempDir()
+	repoRoot := filepath.Join(tempDir, "repo")
+	worktreeDir := filepath.Join(tempDir, "worktree")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repoRoot, ".bin"), directoryPermissions))
+	require.NoError(t, os.MkdirAll(filepath.Join(repoRoot, ".cache"), directoryPermissions))
+	require.NoError(t, os.MkdirAll(worktreeDir, directoryPermissions))
+
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeSymlink, From: ".bin", To: ".bin"},
+				{Type: config.HookTypeSymlink, From: ".cache", To: ".cache", Relative: true},
+				{Type: config.HookTypeSymlink, From: ".missing", To: ".missing"},
+				{Type: config.HookTypeCommand, Command: "echo ok"},
+			},
+		},
+	}
+
+	executor := NewExecutor(cfg, repoRoot)
+	require.NoError(t, os.Symlink(filepath.Join(repoRoot, ".bin"), filepath.Join(worktreeDir, ".bin")))
+	require.NoError(t, os.Symlink(filepath.Join("..", "repo", ".cache"), filepath.Join(worktreeDir, ".cache")))
+
+	t.Run("healthy links are not reported", func(t *testing.T) {
+		dangling, err := executor.FindDanglingSymlinks(worktreeDir)
+		require.NoError(t, err)
+		assert.Empty(t, dangling)
+	})
+
+	t.Run("links whose source was removed are reported", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(filepath.Join(repoRoot, ".cache")))
+
+		dangling, err := executor.FindDanglingSymlinks(worktreeDir)
+		require.NoError(t, err)
+		require.Len(t, dangling, 1)
+		assert.Equal(t, 1, dangling[0].HookIndex)
+		assert.Equal(t, filepath.Join(worktreeDir, ".cache"), dangling[0].LinkPath)
+		assert.Equal(t, filepath.Join("..", "repo", ".cache"), dangling[0].Target)
+	})
+
+	t.Run("regular files at link location are ignored", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(worktreeDir, ".cache")))
+		require.NoError(t, os.WriteFile(filepath.Join(worktreeDir, ".cache"), []byte("x"), 0644))
+
+		dangling, err := executor.FindDanglingSymlinks(worktreeDir)
+		require.NoError(t, err)
+		assert.Empty(t, dangling)
+	})
+}
+
+func TestRelinkSymlinks(t *testing.T) {
+	requireSymlinkSupport(t)
+
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	repoRoot := filepath.Join(tempDir, "repo")
+	oldPath := filepath.Join(tempDir, "worktrees", "feature", "auth")
+	newPath := filepath.Join(tempDir, "worktrees", "login")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repoRoot, ".bin"), directoryPermissions))
+	require.NoError(t, os.MkdirAll(filepath.Join(repoRoot, ".cache"), directoryPermissions))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".cache", "data"), []byte("cache"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".env"), []byte("env"), 0644))
+	require.NoError(t, os.MkdirAll(oldPath, directoryPermissions))
+
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeSymlink, From: ".bin", To: ".bin"},
+				{Type: config.HookTypeSymlink, From: ".cache", To: "nested/.cache", Relative: true},
+				{Type: config.HookTypeSymlink, From: ".env", To: ".env"},
+			},
+		},
+	}
+	executor := NewExecutor(cfg, repoRoot)
+	require.NoError(t, executor.ExecutePostCreateHooks(&bytes.Buffer{}, oldPath))
+
+	// A link that was re-pointed into the worktree itself after it was created.
+	require.NoError(t, os.WriteFile(filepath.Join(oldPath, ".env.local"), []byte("local"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(oldPath, ".env")))
+	require.NoError(t, os.Symlink(filepath.Join(oldPath, ".env.local"), filepath.Join(oldPath, ".env")))
+
+	require.NoError(t, os.Rename(oldPath, newPath))
+
+	var buf bytes.Buffer
+	require.NoError(t, executor.RelinkSymlinks(&buf, oldPath, newPath))
+
+	target, err := os.Readlink(filepath.Join(newPath, ".bin"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repoRoot, ".bin"), target, "links outside the worktree stay as they are")
+
+	target, err = os.Readlink(filepath.Join(newPath, "nested", ".cache"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("..", "..", "..", "repo", ".cache"), target)
+	content, err := os.ReadFile(filepath.Join(newPath, "nested", ".cache", "data"))
+	require.NoError(t, err)
+	assert.Equal(t, "cache", string(content))
+
+	target, err = os.Readlink(filepath.Join(newPath, ".env"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(newPath, ".env.local"), target)
+
+	expected := "  Relinked: " + filepath.Join("nested", ".cache") + " → " +
+		filepath.Join("..", "..", "..", "repo", ".cache") + "\n  Relinked: .env → " + filepath.Join(newPath, ".env.local") + "\n"
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,181 @@
+package hooks
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+// Hook timing statuses.
+const (
+	TimingOK      = "ok"
+	TimingFailed  = "failed"
+	TimingSkipped = "skipped"
+	TimingNotRun  = "not run"
+)
+
+// maxTimingDescription limits hook descriptions in the timing summary to keep rows on one line.
+const maxTimingDescription = 48
+
+// HookTiming records how long one post-create hook took.
+type HookTiming struct {
+	Hook        int           `json:"hook"`
+	Type        string        `json:"type"`
+	Description string        `json:"description"`
+	Status      string        `json:"status"`
+	Duration    time.Duration `json:"-"`
+	DurationMS  int64         `json:"duration_ms"`
+}
+
+// TimingReport is the JSON form of the hook timings of one worktree.
+type TimingReport struct {
+	Worktree string       `json:"worktree"`
+	TotalMS  int64        `json:"total_ms"`
+	Hooks    []HookTiming `json:"hooks"`
+}
+
+// NewTimingReport builds the report for the hooks run in worktreePath.
+func NewTimingReport(worktreePath string, timings []HookTiming) TimingReport {
+	return TimingReport{
+		Worktree: worktreePath,
+		TotalMS:  totalDuration(timings).Milliseconds(),
+		Hooks:    timings,
+	}
+}
+
+// Timings returns the timing of every post-create hook from the last ExecutePostCreateHooks call.
+func (e *Executor) Timings() []HookTiming {
+	return append([]HookTiming(nil), e.timings...)
+}
+
+func (e *Executor) recordTiming(index int, hook *config.Hook, worktreePath, status string, duration time.Duration) {
+	e.timings = append(e.timings, HookTiming{
+		Hook:        index + 1,
+		Type:        hook.Type,
+		Description: e.timingDescription(index, hook, worktreePath),
+		Status:      status,
+		Duration:    duration,
+		DurationMS:  duration.Milliseconds(),
+	})
+}
+
+// recordRemaining records the hooks from index on as not run after a failure.
+func (e *Executor) recordRemaining(index int, worktreePath string) {
+	for i := index; i < len(e.config.Hooks.PostCreate); i++ {
+		hook := &e.config.Hooks.PostCreate[i]
+		status := TimingNotRun
+		if !e.shouldRun(i, hook) {
+			status = TimingSkipped
+		}
+		e.recordTiming(i, hook, worktreePath, status, 0)
+	}
+}
+
+// timingDescription summarizes a hook on a single line, with secrets masked.
+func (e *Executor) timingDescription(index int, hook *config.Hook, worktreePath string) string {
+	var description string
+	switch hook.Type {
+	case config.HookTypeCommand:
+		env, _ := e.commandEnv(index, hook, worktreePath)
+		secrets, _ := e.hookSecrets(hook, env)
+		description = maskSecrets(commandDescription(hook), secrets)
+	default:
+		to := hook.To
+		if to == "" {
+			to = hook.From
+		}
+		description = hook.From + " → " + to
+	}
+
+	description, _, multiline := strings.Cut(description, "\n")
+	if multiline {
+		description += " …"
+	}
+	if utf8.RuneCountInString(description) > maxTimingDescription {
+		description = string([]rune(description)[:maxTimingDescription-1]) + "…"
+	}
+	return description
+}
+
+// WriteTimingSummary writes a table of hook timings with the total and the slowest hook.
+func WriteTimingSummary(w io.Writer, timings []HookTiming) error {
+	if len(timings) == 0 {
+		return nil
+	}
+
+	typeWidth, statusWidth := len("TYPE"), len("STATUS")
+	durations := make([]string, len(timings))
+	durationWidth := len("DURATION")
+	for i, timing := range timings {
+		typeWidth = max(typeWidth, len(timing.Type))
+		statusWidth = max(statusWidth, len(timing.Status))
+		durations[i] = "-"
+		if timing.Status == TimingOK || timing.Status == TimingFailed {
+			durations[i] = formatTimingDuration(timing.Duration)
+		}
+		durationWidth = max(durationWidth, len(durations[i]))
+	}
+
+	if _, err := fmt.Fprintf(w, "\nHook timings:\n  %-3s %-*s %-*s %*s  %s\n",
+		"#", typeWidth, "TYPE", statusWidth, "STATUS", durationWidth, "DURATION", "DESCRIPTION"); err != nil {
+		return err
+	}
+	for i, timing := range timings {
+		if _, err := fmt.Fprintf(w, "  %-3d %-*s %-*s %*s  %s\n", timing.Hook,
+			typeWidth, timing.Type, statusWidth, timing.Status, durationWidth, durations[i],
+			timing.Description); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "  Total: %s", formatTimingDuration(totalDuration(timings))); err != nil {
+		return err
+	}
+	if slowest := slowestTiming(timings); slowest != nil {
+		if _, err := fmt.Fprintf(w, " (slowest: hook %d, %s)", slowest.Hook,
+			formatTimingDuration(slowest.Duration)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func totalDuration(timings []HookTiming) time.Duration {
+	var total time.Duration
+	for _, timing := range timings {
+		total += timing.Duration
+	}
+	return total
+}
+
+// slowestTiming returns the slowest hook that ran, or nil when fewer than two hooks ran.
+func slowestTiming(timings []HookTiming) *HookTiming {
+	var slowest *HookTiming
+	ran := 0
+	for i := range timings {
+		if timings[i].Status != TimingOK && timings[i].Status != TimingFailed {
+			continue
+		}
+		ran++
+		if slowest == nil || timings[i].Duration > slowest.Duration {
+			slowest = &timings[i]
+		}
+	}
+	if ran < 2 { //nolint:mnd // a single hook is trivially the slowest
+		return nil
+	}
+	return slowest
+}
+
+// formatTimingDuration shows sub-second durations in milliseconds and longer ones to a tenth of a second.
+func formatTimingDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second / 10).String() //nolint:mnd // tenths of a second
+}
@@ -0,0 +1,118 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func TestExecutePostCreateHooks_RecordsTimings(t *testing.T) {
+	repoRoot := t.TempDir()
+	worktreeDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoRoot, ".env"), []byte("A=1"), 0o600))
+
+	cfg := &config.Config{
+		Hooks: config.Hooks{
+			PostCreate: []config.Hook{
+				{Type: config.HookTypeCopy, From: ".env", To: ".env"},
+				{Type: config.HookTypeSymlink, From: ".env", To: "skipped/.env"},
+				{Type: config.HookTypeCopy, From: "missing", To: "missing"},
+				{Type: config.HookTypeCopy, From: ".env", To: ".env.local"},
+			},
+		},
+	}
+	executor := NewExecutorWithOptions(cfg, repoRoot, Options{
+		Filter: func(_ int, hook *config.Hook) bool { return hook.Type == config.HookTypeCopy },
+	})
+
+	var buf bytes.Buffer
+	require.Error(t, executor.ExecutePostCreateHooks(&buf, worktreeDir))
+
+	timings := executor.Timings()
+	require.Len(t, timings, 4)
+	statuses := make([]string, 0, len(timings))
+	for _, timing := range timings {
+		statuses = append(statuses, timing.Status)
+	}
+	assert.Equal(t, []string{TimingOK, TimingSkipped, TimingFailed, TimingNotRun}, statuses)
+	assert.Equal(t, ".env → skipped/.env", timings[1].Description)
+	assert.Zero(t, timings[1].Duration)
+	assert.Zero(t, timings[3].Duration)
+}
+
+func TestTimingDescription(t *testing.T) {
+	t.Setenv("WTP_TIMING_TOKEN", "super-secret-token")
+	executor := NewExecutor(&config.Config{}, t.TempDir())
+
+	tests := []struct {
+		name     string
+		hook     config.Hook
+		expected string
+	}{
+		{
+			name:     "copy hook",
+			hook:     config.Hook{Type: config.HookTypeCopy, From: ".env", To: "config/.env"},
+			expected: ".env → config/.env",
+		},
+		{
+			name:     "multi-line run script",
+			hook:     config.Hook{Type: config.HookTypeCommand, Run: "npm ci\nnpm run build\n"},
+			expected: "npm ci …",
+		},
+		{
+			name:     "long command",
+			hook:     config.Hook{Type: config.HookTypeCommand, Command: strings.Repeat("x", 60)},
+			expected: strings.Repeat("x", maxTimingDescription-1) + "…",
+		},
+		{
+			name: "masked secret",
+			hook: config.Hook{
+				Type:    config.HookTypeCommand,
+				Command: "login super-secret-token",
+				Mask:    []string{"WTP_TIMING_TOKEN"},
+			},
+			expected: "login ***",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, executor.timingDescription(0, &tt.hook, t.TempDir()))
+		})
+	}
+}
+
+func TestWriteTimingSummary(t *testing.T) {
+	timings := []HookTiming{
+		{Hook: 1, Type: "copy", Description: ".env → .env", Status: TimingOK, Duration: 3 * time.Millisecond},
+		{Hook: 2, Type: "command", Description: "npm install", Status: TimingOK, Duration: 192400 * time.Millisecond},
+		{Hook: 3, Type: "command", Description: "make db", Status: TimingFailed, Duration: 1200 * time.Millisecond},
+		{Hook: 4, Type: "symlink", Description: ".bin → .bin", Status: TimingNotRun},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTimingSummary(&buf, timings))
+
+	expected := "\nHook timings:\n" +
+		"  #   TYPE    STATUS  DURATION  DESCRIPTION\n" +
+		"  1   copy    ok           3ms  .env → .env\n" +
+		"  2   command ok       3m12.4s  npm install\n" +
+		"  3   command failed      1.2s  make db\n" +
+		"  4   symlink not run        -  .bin → .bin\n" +
+		"  Total: 3m13.6s (slowest: hook 2, 3m12.4s)\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteTimingSummary_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteTimingSummary(&buf, nil))
+	assert.Empty(t, buf.String())
+}
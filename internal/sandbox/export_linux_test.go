@@ -0,0 +1,3 @@
+package sandbox
+
+func clearSystemPaths() { systemPaths = nil }
@@ -0,0 +1,67 @@
+// Package sandbox restricts where command hooks may write.
+//
+// On Linux the restriction uses Landlock. Go cannot restrict an already running,
+// multi-threaded process reliably, so a sandboxed hook is started through the
+// hidden 'wtp __sandbox-exec' command: it restricts its own thread and then
+// replaces itself with the hook program, which inherits the restriction.
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ExecCommand is the hidden wtp command that runs a program inside the sandbox.
+const ExecCommand = "__sandbox-exec"
+
+const allowFlag = "--allow"
+
+// executable returns the path of the wtp binary; tests replace it.
+var executable = os.Executable
+
+// Policy lists the paths a sandboxed program may write to. Reading is not restricted.
+type Policy struct {
+	Writable []string
+}
+
+// Wrap rewrites cmd to run through 'wtp __sandbox-exec' under policy. Call
+// Available first: on systems without sandbox support the wrapped command fails.
+func Wrap(cmd *exec.Cmd, policy Policy) error {
+	if cmd.Err != nil {
+		return cmd.Err
+	}
+	self, err := executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate wtp for the sandbox: %w", err)
+	}
+
+	args := []string{self, ExecCommand}
+	for _, path := range policy.Writable {
+		args = append(args, allowFlag, path)
+	}
+	args = append(args, "--", cmd.Path)
+	args = append(args, cmd.Args[1:]...)
+
+	cmd.Path = self
+	cmd.Args = args
+	return nil
+}
+
+// Exec restricts the current process as described by args, as built by Wrap,
+// and replaces it with the program that follows "--". It only returns on error.
+func Exec(args []string) error {
+	var policy Policy
+	for len(args) > 0 && args[0] != "--" {
+		if args[0] != allowFlag || len(args) < 2 { //nolint:mnd // the flag and its value
+			return fmt.Errorf("invalid %s arguments: %s", ExecCommand, strings.Join(args, " "))
+		}
+		policy.Writable = append(policy.Writable, args[1])
+		args = args[2:]
+	}
+	if len(args) < 2 { //nolint:mnd // "--" and the program
+		return fmt.Errorf("%s requires a program after '--'", ExecCommand)
+	}
+	return restrictAndExec(policy, args[1:])
+}
@@ -0,0 +1,150 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Landlock ABI versions that added access rights wtp handles.
+const (
+	abiRefer    = 2
+	abiTruncate = 3
+)
+
+// systemPaths are the temporary directories and devices that ordinary scripts
+// write to; they stay writable in the sandbox.
+var systemPaths = []string{
+	"/tmp", "/var/tmp", "/dev/shm",
+	"/dev/null", "/dev/zero", "/dev/full", "/dev/tty", "/dev/pts",
+}
+
+// rulesetAttr is the ABI 1 prefix of struct landlock_ruleset_attr, which every kernel accepts.
+type rulesetAttr struct {
+	handledAccessFS uint64
+}
+
+// Available reports why sandboxed hooks cannot run, or nil if they can.
+func Available() error {
+	_, err := abiVersion()
+	return err
+}
+
+func abiVersion() (int, error) {
+	version, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION)
+	if errno != 0 {
+		if errors.Is(errno, unix.ENOSYS) || errors.Is(errno, unix.EOPNOTSUPP) {
+			return 0, fmt.Errorf("the kernel does not support Landlock")
+		}
+		return 0, fmt.Errorf("failed to query Landlock: %w", errno)
+	}
+	return int(version), nil
+}
+
+// writeAccess returns the write rights handled by the ruleset for a Landlock ABI
+// version, and the subset that applies to files rather than directories.
+func writeAccess(abi int) (all, file uint64) {
+	all = unix.LANDLOCK_ACCESS_FS_WRITE_FILE |
+		unix.LANDLOCK_ACCESS_FS_REMOVE_DIR |
+		unix.LANDLOCK_ACCESS_FS_REMOVE_FILE |
+		unix.LANDLOCK_ACCESS_FS_MAKE_CHAR |
+		unix.LANDLOCK_ACCESS_FS_MAKE_DIR |
+		unix.LANDLOCK_ACCESS_FS_MAKE_REG |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_FIFO |
+		unix.LANDLOCK_ACCESS_FS_MAKE_BLOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SYM
+	file = unix.LANDLOCK_ACCESS_FS_WRITE_FILE
+	if abi >= abiRefer {
+		all |= unix.LANDLOCK_ACCESS_FS_REFER
+	}
+	if abi >= abiTruncate {
+		all |= unix.LANDLOCK_ACCESS_FS_TRUNCATE
+		file |= unix.LANDLOCK_ACCESS_FS_TRUNCATE
+	}
+	return all, file
+}
+
+func restrictAndExec(policy Policy, argv []string) error {
+	program, err := exec.LookPath(argv[0])
+	if err != nil {
+		return err
+	}
+
+	// Landlock restricts the calling thread; exec from that same thread so that
+	// the program inherits the restriction.
+	runtime.LockOSThread()
+	if err := restrict(policy); err != nil {
+		return err
+	}
+	// #nosec G204 -- the program is the hook command wtp was asked to run
+	return syscall.Exec(program, argv, os.Environ())
+}
+
+func restrict(policy Policy) error {
+	abi, err := abiVersion()
+	if err != nil {
+		return err
+	}
+	all, file := writeAccess(abi)
+
+	attr := rulesetAttr{handledAccessFS: all}
+	fd, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET,
+		uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr), 0)
+	if errno != 0 {
+		return fmt.Errorf("failed to create Landlock ruleset: %w", errno)
+	}
+	ruleset := int(fd)
+	defer func() { _ = unix.Close(ruleset) }()
+
+	for _, path := range append(append([]string{}, policy.Writable...), systemPaths...) {
+		if err := allowWrites(ruleset, path, all, file); err != nil {
+			return err
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("failed to set no_new_privs: %w", err)
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_RESTRICT_SELF, uintptr(ruleset), 0, 0); errno != 0 {
+		return fmt.Errorf("failed to enforce Landlock ruleset: %w", errno)
+	}
+	return nil
+}
+
+// allowWrites adds a rule that allows writing beneath path. Paths that do not
+// exist are skipped, so an allowlisted cache directory may be created later.
+func allowWrites(ruleset int, path string, all, file uint64) error {
+	fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if errors.Is(err, unix.ENOENT) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open sandbox path %s: %w", path, err)
+	}
+	defer func() { _ = unix.Close(fd) }()
+
+	var stat unix.Stat_t
+	if err := unix.Fstat(fd, &stat); err != nil {
+		return fmt.Errorf("failed to stat sandbox path %s: %w", path, err)
+	}
+	access := all
+	if stat.Mode&unix.S_IFMT != unix.S_IFDIR {
+		access = file
+	}
+
+	rule := unix.LandlockPathBeneathAttr{Allowed_access: access, Parent_fd: int32(fd)} // #nosec G115 -- fds fit in int32
+	if _, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, uintptr(ruleset), unix.LANDLOCK_RULE_PATH_BENEATH,
+		uintptr(unsafe.Pointer(&rule)), 0, 0, 0); errno != 0 {
+		return fmt.Errorf("failed to allow writes to %s: %w", path, errno)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package sandbox
+
+import "fmt"
+
+// Available reports why sandboxed hooks cannot run, or nil if they can.
+func Available() error {
+	return fmt.Errorf("sandboxed hooks require Linux with Landlock")
+}
+
+func restrictAndExec(Policy, []string) error {
+	return Available()
+}
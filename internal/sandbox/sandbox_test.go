@@ -0,0 +1,70 @@
+package sandbox
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain lets the test binary stand in for wtp: Wrap runs it with ExecCommand.
+// Test directories live under /tmp, so the stand-in does not allow the system paths.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == ExecCommand {
+		clearSystemPaths()
+		if err := Exec(os.Args[2:]); err != nil {
+			_, _ = os.Stderr.WriteString(err.Error() + "\n")
+		}
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func TestWrap(t *testing.T) {
+	t.Cleanup(func() { executable = os.Executable })
+	executable = func() (string, error) { return "/usr/local/bin/wtp", nil }
+
+	cmd := exec.Command("/bin/sh", "-c", "npm ci")
+	require.NoError(t, Wrap(cmd, Policy{Writable: []string{"/work/tree", "/tmp"}}))
+
+	assert.Equal(t, "/usr/local/bin/wtp", cmd.Path)
+	assert.Equal(t, []string{
+		"/usr/local/bin/wtp", ExecCommand,
+		"--allow", "/work/tree", "--allow", "/tmp",
+		"--", "/bin/sh", "-c", "npm ci",
+	}, cmd.Args)
+}
+
+func TestExec_InvalidArguments(t *testing.T) {
+	tests := map[string][]string{
+		"missing separator":  {"--allow", "/tmp", "sh"},
+		"missing program":    {"--allow", "/tmp", "--"},
+		"missing allow path": {"--allow"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, Exec(args))
+		})
+	}
+}
+
+func TestSandboxRestrictsWrites(t *testing.T) {
+	if err := Available(); err != nil {
+		t.Skipf("sandbox unavailable: %v", err)
+	}
+
+	writable := t.TempDir()
+	protected := t.TempDir()
+	script := "echo ok > allowed.txt && echo denied > " + filepath.Join(protected, "denied.txt")
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Dir = writable
+	require.NoError(t, Wrap(cmd, Policy{Writable: []string{writable}}))
+
+	output, err := cmd.CombinedOutput()
+	require.Error(t, err, string(output))
+	assert.FileExists(t, filepath.Join(writable, "allowed.txt"))
+	assert.NoFileExists(t, filepath.Join(protected, "denied.txt"))
+}
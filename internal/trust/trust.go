@@ -0,0 +1,252 @@
+// Package trust records which command hooks a user has approved for each repository.
+//
+// Command hooks run arbitrary programs, and a pulled .wtp.yml can change them.
+// Like direnv, wtp only runs command hooks whose fingerprint matches the one
+// the user last trusted for the repository.
+package trust
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+const (
+	dirPermissions  = 0o700
+	filePermissions = 0o600
+	// shortHashLength is the number of hex characters kept from hashes used in file names and script digests.
+	shortHashLength = 16
+)
+
+// Fingerprint identifies the command hooks of a repository's configuration.
+type Fingerprint struct {
+	// Repo is the main worktree path the hooks belong to.
+	Repo string `json:"repo"`
+	// Hooks is a readable description of every command hook, including a hash of script files.
+	Hooks string `json:"hooks"`
+	// Hash is the SHA-256 of Hooks.
+	Hash string `json:"hash"`
+}
+
+// Empty reports whether the configuration has no command hooks to trust.
+func (f Fingerprint) Empty() bool {
+	return f.Hooks == ""
+}
+
+// NewFingerprint describes the command hooks of cfg for the repository at repoRoot.
+// Copy and symlink hooks cannot run code and are not part of the fingerprint.
+func NewFingerprint(cfg *config.Config, repoRoot string) Fingerprint {
+	repo := repoRoot
+	if resolved, err := filepath.EvalSymlinks(repoRoot); err == nil {
+		repo = resolved
+	}
+
+	var b strings.Builder
+	if cfg != nil {
+		for i := range cfg.Hooks.PostCreate {
+			hook := &cfg.Hooks.PostCreate[i]
+			if hook.Type == config.HookTypeCommand {
+				describeHook(&b, i, hook, repoRoot)
+			}
+		}
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return Fingerprint{Repo: repo, Hooks: b.String(), Hash: hex.EncodeToString(sum[:])}
+}
+
+func describeHook(b *strings.Builder, index int, hook *config.Hook, repoRoot string) {
+	fmt.Fprintf(b, "hook %d (command):\n", index+1)
+	writeField(b, "command", hook.Command)
+	writeField(b, "run", hook.Run)
+	if hook.Script != "" {
+		writeField(b, "script", hook.Script+" ("+fileDigest(hook.Script, repoRoot)+")")
+	}
+	writeField(b, "shell", hook.Shell)
+	writeField(b, "work_dir", hook.WorkDir)
+	if hook.Interactive {
+		writeField(b, "interactive", "true")
+	}
+	writeField(b, "env_mode", hook.EnvMode)
+	for _, pattern := range hook.EnvAllow {
+		writeField(b, "env_allow", pattern)
+	}
+	if hook.EnvFile != "" {
+		writeField(b, "env_file", hook.EnvFile+" ("+fileDigest(hook.EnvFile, repoRoot)+")")
+	}
+	if hook.Sandbox {
+		writeField(b, "sandbox", "true")
+	}
+	for _, path := range hook.SandboxAllow {
+		writeField(b, "sandbox_allow", path)
+	}
+
+	keys := make([]string, 0, len(hook.Env))
+	for key := range hook.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeField(b, "env", key+"="+hook.Env[key])
+	}
+}
+
+func writeField(b *strings.Builder, name, value string) {
+	if value == "" {
+		return
+	}
+	lines := strings.Split(strings.TrimRight(value, "\n"), "\n")
+	fmt.Fprintf(b, "  %s: %s\n", name, lines[0])
+	for _, line := range lines[1:] {
+		fmt.Fprintf(b, "    %s\n", line)
+	}
+}
+
+// fileDigest identifies the content of a hook's script or env_file, so that editing
+// either requires new trust: an env_file can set BASH_ENV or LD_PRELOAD.
+func fileDigest(file, repoRoot string) string {
+	path := file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoRoot, path)
+	}
+	// #nosec G304 -- the path comes from the project configuration being fingerprinted
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "missing"
+	}
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])[:shortHashLength]
+}
+
+// Store persists trusted fingerprints, one file per repository.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store that keeps its records in dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultStore returns the store in the user's configuration directory.
+func DefaultStore() (*Store, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return NewStore(filepath.Join(configDir, "wtp", "trust")), nil
+}
+
+// Trusted returns the fingerprint last trusted for the repository, if any.
+func (s *Store) Trusted(repo string) (Fingerprint, bool, error) {
+	// #nosec G304 -- the record path is derived from a hash inside the store directory
+	data, err := os.ReadFile(s.recordPath(repo))
+	if os.IsNotExist(err) {
+		return Fingerprint{}, false, nil
+	}
+	if err != nil {
+		return Fingerprint{}, false, fmt.Errorf("failed to read trust record: %w", err)
+	}
+
+	var fp Fingerprint
+	if err := json.Unmarshal(data, &fp); err != nil {
+		return Fingerprint{}, false, fmt.Errorf("failed to parse trust record: %w", err)
+	}
+	return fp, fp.Repo == repo, nil
+}
+
+// IsTrusted reports whether fp matches the fingerprint trusted for its repository.
+// A fingerprint without command hooks is always trusted.
+func (s *Store) IsTrusted(fp Fingerprint) (bool, error) {
+	if fp.Empty() {
+		return true, nil
+	}
+	trusted, ok, err := s.Trusted(fp.Repo)
+	if err != nil || !ok {
+		return false, err
+	}
+	return trusted.Hash == fp.Hash, nil
+}
+
+// Trust records fp as trusted for its repository.
+func (s *Store) Trust(fp Fingerprint) error {
+	if err := os.MkdirAll(s.dir, dirPermissions); err != nil {
+		return fmt.Errorf("failed to create trust directory: %w", err)
+	}
+	data, err := json.MarshalIndent(fp, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.recordPath(fp.Repo), data, filePermissions); err != nil {
+		return fmt.Errorf("failed to write trust record: %w", err)
+	}
+	return nil
+}
+
+// Revoke forgets any trust recorded for the repository.
+func (s *Store) Revoke(repo string) error {
+	if err := os.Remove(s.recordPath(repo)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove trust record: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) recordPath(repo string) string {
+	sum := sha256.Sum256([]byte(repo))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])[:shortHashLength]+".json")
+}
+
+// Diff renders a line diff from previous to current, marking removed lines
+// with "- ", added lines with "+ " and unchanged lines with "  ".
+func Diff(previous, current string) string {
+	before := splitLines(previous)
+	after := splitLines(current)
+
+	// lcs[i][j] is the length of the longest common subsequence of before[i:] and after[j:].
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var b strings.Builder
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			b.WriteString("  " + before[i] + "\n")
+			i++
+			j++
+		case i < len(before) && (j == len(after) || lcs[i+1][j] >= lcs[i][j+1]):
+			b.WriteString("- " + before[i] + "\n")
+			i++
+		default:
+			b.WriteString("+ " + after[j] + "\n")
+			j++
+		}
+	}
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
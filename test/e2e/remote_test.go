@@ -172,3 +172,52 @@ func TestSimplifiedInterfaceBehavior(t *testing.T) {
 		framework.AssertWorktreeExists(t, repo, "new-branch")
 	})
 }
+
+func TestWorktreeAddPullRequest(t *testing.T) {
+	env := framework.NewTestEnvironment(t)
+	defer env.Cleanup()
+
+	// The hosting side publishes pull request heads outside refs/heads.
+	hosting := env.CreateTestRepo("pr-hosting")
+	hosting.CreateBranch("contributor/fix")
+	hosting.CheckoutBranch("contributor/fix")
+	hosting.CommitFile("fix.txt", "fixed", "Fix the bug")
+	env.RunInDir(hosting.Path(), "git", "update-ref", "refs/pull/42/head", "contributor/fix")
+	env.RunInDir(hosting.Path(), "git", "update-ref", "refs/merge-requests/7/head", "contributor/fix")
+	hosting.CheckoutBranch("main")
+
+	repo := env.CreateTestRepo("pr-review")
+	repo.AddRemote("upstream", hosting.Path())
+
+	output, err := repo.RunWTP("add", "--pr", "42", "--remote", "upstream")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "Fetched pull request #42 from upstream into pr/42")
+	framework.AssertWorktreeCreated(t, output, "pr/42")
+	framework.AssertTrue(t, env.FileExists(env.TmpDir()+"/worktrees/pr/42/fix.txt"), "pull request is checked out")
+	framework.AssertEqual(t, hosting.GetBranchCommitHash("contributor/fix"), repo.GetBranchCommitHash("pr/42"))
+
+	repo.WriteConfig(`version: "1.0"
+defaults:
+  pr_remote: upstream
+  pr_refspec: refs/merge-requests/{n}/head
+hooks:
+  post_create:
+    - type: command
+      command: echo "$GIT_WTP_IS_NEW_BRANCH $GIT_WTP_REMOTE" > "$GIT_WTP_REPO_ROOT/hook-env"
+`)
+	output, err = repo.RunWTP("add", "--pr", "7", "--trust")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "Fetched pull request #7 from upstream into pr/7")
+	framework.AssertEqual(t, "true upstream\n", repo.ReadFile("hook-env"))
+
+	// Adding the pull request again reuses the branch the first fetch created.
+	_, err = repo.RunWTP("remove", "pr/7")
+	framework.AssertNoError(t, err)
+	_, err = repo.RunWTP("add", "--pr", "7", "--trust")
+	framework.AssertNoError(t, err)
+	framework.AssertEqual(t, "false upstream\n", repo.ReadFile("hook-env"))
+
+	output, err = repo.RunWTP("add", "--pr", "404", "--trust")
+	framework.AssertError(t, err)
+	framework.AssertOutputContains(t, output, "failed to fetch pull request #404 from upstream")
+}
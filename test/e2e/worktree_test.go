@@ -161,6 +161,201 @@ defaults:
 	})
 }
 
+func TestWorktreePrune(t *testing.T) {
+	env := framework.NewTestEnvironment(t)
+	defer env.Cleanup()
+
+	repo := env.CreateTestRepo("prune-test")
+	for _, branch := range []string{"feature/stale", "feature/orphan", "feature/live"} {
+		repo.CreateBranch(branch)
+		_, err := repo.RunWTP("add", branch)
+		framework.AssertNoError(t, err)
+	}
+	// A branch left behind by 'wtp remove' is a candidate; a plain merged branch is not.
+	repo.CreateBranch("feature/merged")
+	_, err := repo.RunWTP("add", "feature/merged")
+	framework.AssertNoError(t, err)
+	_, err = repo.RunWTP("remove", "feature/merged")
+	framework.AssertNoError(t, err)
+	repo.CreateBranch("develop")
+
+	worktreesDir := env.TmpDir() + "/worktrees"
+	env.RunInDir(env.TmpDir(), "rm", "-rf", worktreesDir+"/feature/stale")
+	env.RunInDir(repo.Path(), "rm", "-rf", ".git/worktrees/orphan")
+
+	output, err := repo.RunWTP("prune", "--dry-run")
+	framework.AssertNoError(t, err)
+	framework.AssertMultipleStringsInOutput(t, output, []string{
+		"Stale worktrees", "feature/stale",
+		"Orphaned directories", "feature/orphan",
+		"Branches merged into main", "feature/merged",
+	})
+	framework.AssertFalse(t, strings.Contains(output, "feature/live"), "live worktrees are kept")
+	framework.AssertFalse(t, strings.Contains(output, "develop"), "branches without a worktree are kept")
+	framework.AssertWorktreeCount(t, repo, 3)
+
+	output, err = repo.RunWTP("prune")
+	framework.AssertError(t, err)
+	framework.AssertOutputContains(t, output, "wtp prune --yes")
+
+	output, err = repo.RunWTP("prune", "--yes")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "Pruned 1 stale worktree(s)")
+	framework.AssertOutputContains(t, output, "Removed branch 'feature/merged'")
+	framework.AssertFalse(t, strings.Contains(output, "develop"), "branches without a worktree are kept")
+	framework.AssertWorktreeCount(t, repo, 2)
+	framework.AssertFalse(t, env.FileExists(worktreesDir+"/feature/orphan"), "orphaned directory is removed")
+	framework.AssertTrue(t, env.FileExists(worktreesDir+"/feature/live"), "live worktree is kept")
+
+	output, err = repo.RunWTP("prune", "--yes")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "Nothing to prune")
+}
+
+func TestWorktreeMove(t *testing.T) {
+	env := framework.NewTestEnvironment(t)
+	defer env.Cleanup()
+
+	repo := env.CreateTestRepo("move-test")
+	env.WriteFile(repo.Path()+"/.shared", "shared")
+	repo.WriteConfig(`version: "1.0"
+hooks:
+  post_create:
+    - type: symlink
+      from: ".shared"
+      to: "nested/.shared"
+      relative: true
+`)
+	repo.CreateBranch("feature/auth")
+	repo.CreateBranch("feature/taken")
+	for _, branch := range []string{"feature/auth", "feature/taken"} {
+		_, err := repo.RunWTP("add", branch)
+		framework.AssertNoError(t, err)
+	}
+
+	output, err := repo.RunWTP("move", "feature/auth", "feature/taken")
+	framework.AssertError(t, err)
+	framework.AssertOutputContains(t, output, "already exists")
+
+	// The branch is checked before the worktree moves, so nothing is left half done.
+	repo.CreateBranch("login-taken")
+	output, err = repo.RunWTP("move", "feature/auth", "login-taken", "--with-branch")
+	framework.AssertError(t, err)
+	framework.AssertOutputContains(t, output, "a branch named 'login-taken' already exists")
+	framework.AssertWorktreeExists(t, repo, "feature/auth")
+
+	output, err = repo.RunWTP("move", "feature/auth", "login", "--with-branch")
+	framework.AssertNoError(t, err)
+	framework.AssertMultipleStringsInOutput(t, output, []string{
+		"Moved worktree 'feature/auth' to 'login'",
+		"Renamed branch 'feature/auth' to 'login'",
+		"Relinked: nested/.shared",
+	})
+
+	worktreesDir := env.TmpDir() + "/worktrees"
+	framework.AssertTrue(t, env.FileExists(worktreesDir+"/login/nested/.shared"), "relative symlink still resolves")
+	framework.AssertFalse(t, env.FileExists(worktreesDir+"/feature/auth"), "old path is gone")
+	framework.AssertWorktreeCount(t, repo, 3)
+	framework.AssertWorktreeExists(t, repo, "login")
+}
+
+func TestHooksRunAgain(t *testing.T) {
+	env := framework.NewTestEnvironment(t)
+	defer env.Cleanup()
+
+	repo := env.CreateTestRepo("hooks-run-again")
+	env.WriteFile(repo.Path()+"/.env", "A=1")
+	repo.WriteConfig(`version: "1.0"
+hooks:
+  post_create:
+    - type: symlink
+      from: ".env"
+      to: ".env"
+    - type: copy
+      from: ".env"
+      to: ".env.local"
+`)
+	_, err := repo.RunWTP("add", "-b", "feat")
+	framework.AssertNoError(t, err)
+
+	output, err := repo.RunWTP("hooks", "run", "feat")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "Already linked: .env → .env")
+	framework.AssertOutputContains(t, output, "Hook 2 completed")
+}
+
+func TestBareClone(t *testing.T) {
+	env := framework.NewTestEnvironment(t)
+	defer env.Cleanup()
+
+	source := env.CreateTestRepo("clone-source")
+	source.CreateBranch("feature/auth")
+
+	clones := env.CreateNonRepoDir("clones")
+	output, err := clones.RunWTP("clone", source.Path(), "proj")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "Created default worktree 'main'")
+
+	projDir := env.TmpDir() + "/clones/proj"
+	framework.AssertTrue(t, env.FileExists(projDir+"/.bare/HEAD"), "bare repository is created")
+	framework.AssertTrue(t, env.FileExists(projDir+"/main/README.md"), "default worktree is checked out")
+
+	// wtp works from the project directory itself, next to the bare repository
+	proj := env.CreateNonRepoDir("clones/proj")
+	_, err = proj.RunWTP("add", "feature/auth")
+	framework.AssertNoError(t, err)
+	framework.AssertTrue(t, env.FileExists(projDir+"/worktrees/feature/auth/README.md"),
+		"worktrees go to base_dir next to the default worktree")
+
+	output, err = proj.RunWTP("cd", "@")
+	framework.AssertNoError(t, err)
+	framework.AssertEqual(t, projDir+"/main", strings.TrimSpace(output))
+
+	output, err = proj.RunWTP("list")
+	framework.AssertNoError(t, err)
+	framework.AssertMultipleStringsInOutput(t, output, []string{"unmanaged, bare", "@", "feature/auth"})
+
+	output, err = clones.RunWTP("clone", source.Path(), "proj")
+	framework.AssertError(t, err)
+	framework.AssertOutputContains(t, output, "already exists")
+}
+
+func TestWorktreeCleanMerged(t *testing.T) {
+	env := framework.NewTestEnvironment(t)
+	defer env.Cleanup()
+
+	repo := env.CreateTestRepo("clean-merged")
+	for _, branch := range []string{"feature/merged", "feature/squashed", "feature/wip"} {
+		repo.CreateBranch(branch)
+		repo.CheckoutBranch(branch)
+		repo.CommitFile(strings.ReplaceAll(branch, "/", "-")+".txt", branch, "Work on "+branch)
+		repo.CommitFile(strings.ReplaceAll(branch, "/", "-")+"-more.txt", branch, "More work on "+branch)
+		repo.CheckoutBranch("main")
+	}
+	env.RunInDir(repo.Path(), "git", "merge", "--no-ff", "-m", "Merge feature/merged", "feature/merged")
+	env.RunInDir(repo.Path(), "git", "merge", "--squash", "feature/squashed")
+	env.RunInDir(repo.Path(), "git", "commit", "-m", "Squash feature/squashed")
+
+	for _, branch := range []string{"feature/merged", "feature/squashed", "feature/wip"} {
+		_, err := repo.RunWTP("add", branch)
+		framework.AssertNoError(t, err)
+	}
+
+	output, err := repo.RunWTP("clean", "--merged", "--dry-run")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "feature/squashed")
+	framework.AssertOutputContains(t, output, "squash-merged")
+	framework.AssertFalse(t, strings.Contains(output, "feature/wip"), "unmerged worktrees are not listed")
+	framework.AssertWorktreeCount(t, repo, 4)
+
+	output, err = repo.RunWTP("clean", "--merged", "--with-branch", "--yes")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "Removed branch 'feature/merged'")
+	framework.AssertOutputContains(t, output, "Removed branch 'feature/squashed'")
+	framework.AssertWorktreeCount(t, repo, 2)
+	framework.AssertWorktreeExists(t, repo, "feature/wip")
+}
+
 func TestWorktreeList(t *testing.T) {
 	env := framework.NewTestEnvironment(t)
 	defer env.Cleanup()
@@ -193,6 +388,36 @@ func TestWorktreeList(t *testing.T) {
 			strings.Contains(output, "@") || strings.Contains(output, "main"),
 			"Should show main worktree")
 	})
+
+	t.Run("LockAndUnlock", func(t *testing.T) {
+		repo := env.CreateTestRepo("list-lock")
+		repo.CreateBranch("feature/usb")
+
+		_, err := repo.RunWTP("add", "feature/usb")
+		framework.AssertNoError(t, err)
+
+		output, err := repo.RunWTP("lock", "feature/usb", "--reason", "on a usb drive")
+		framework.AssertNoError(t, err)
+		framework.AssertOutputContains(t, output, "Locked worktree 'feature/usb'")
+
+		output, err = repo.RunWTP("list")
+		framework.AssertNoError(t, err)
+		framework.AssertOutputContains(t, output, "managed, locked")
+
+		output, err = repo.RunWTP("list", "--format", "{{.Name}}: {{.Locked}} {{.LockReason}}")
+		framework.AssertNoError(t, err)
+		framework.AssertOutputContains(t, output, "feature/usb: true on a usb drive")
+
+		_, err = repo.RunWTP("remove", "feature/usb")
+		framework.AssertError(t, err)
+
+		output, err = repo.RunWTP("unlock", "feature/usb")
+		framework.AssertNoError(t, err)
+		framework.AssertOutputContains(t, output, "Unlocked worktree 'feature/usb'")
+
+		_, err = repo.RunWTP("remove", "feature/usb")
+		framework.AssertNoError(t, err)
+	})
 }
 
 func TestWorktreeValidation(t *testing.T) {
@@ -283,8 +508,16 @@ hooks:
       command: touch hook-executed.txt`
 		env.WriteFile(repo.Path()+"/.wtp.yml", configContent)
 
+		// Command hooks from an untrusted .wtp.yml must not run
+		output, err := repo.RunWTP("add", "-b", "feature/untrusted")
+		framework.AssertError(t, err)
+		framework.AssertOutputContains(t, output, "command hooks in .wtp.yml are not trusted")
+		framework.AssertOutputContains(t, output, "command: touch hook-executed.txt")
+		framework.AssertFalse(t, env.FileExists(env.TmpDir()+"/worktrees/feature/untrusted"),
+			"Worktree should not be created for untrusted hooks")
+
 		// Create worktree with hooks
-		output, err := repo.RunWTP("add", "-b", "feature/hooks")
+		output, err = repo.RunWTP("add", "-b", "feature/hooks", "--trust")
 		framework.AssertNoError(t, err)
 
 		// Verify hooks were executed
@@ -299,4 +532,99 @@ hooks:
 		framework.AssertNoError(t, err)
 		framework.AssertEqual(t, "template content", string(copiedContent))
 	})
+
+	t.Run("HookExportsReachExec", func(t *testing.T) {
+		repo := env.CreateTestRepo("config-hook-exports")
+
+		configContent := `version: "1.0"
+defaults:
+  base_dir: ../worktrees
+hooks:
+  post_create:
+    - type: command
+      command: echo "DB_NAME=app_$GIT_WTP_WORKTREE_NAME" >> "$GIT_WTP_ENV"
+    - type: command
+      command: echo "$DB_NAME" > hook-db.txt`
+		env.WriteFile(repo.Path()+"/.wtp.yml", configContent)
+
+		// Arguments cannot contain shell metacharacters, so --exec runs a script
+		execScript := env.TmpDir() + "/exec-db.sh"
+		env.WriteFile(execScript, `echo "$DB_NAME" > exec-db.txt`)
+
+		_, err := repo.RunWTP("add", "-b", "exports", "--trust", "--exec", "sh "+execScript)
+		framework.AssertNoError(t, err)
+
+		worktreePath := env.TmpDir() + "/worktrees/exports"
+		for _, name := range []string{"hook-db.txt", "exec-db.txt"} {
+			content, err := os.ReadFile(worktreePath + "/" + name)
+			framework.AssertNoError(t, err)
+			framework.AssertEqual(t, "app_exports\n", string(content))
+		}
+	})
+}
+
+func TestWorktreeAddSubmodules(t *testing.T) {
+	// The submodule is cloned from a local path, which git refuses by default.
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+
+	env := framework.NewTestEnvironment(t)
+	defer env.Cleanup()
+
+	library := env.CreateTestRepo("submodule-library")
+	repo := env.CreateTestRepo("submodule-superproject")
+	env.RunInDir(repo.Path(), "git", "submodule", "add", library.Path(), "libs/lib")
+	env.RunInDir(repo.Path(), "git", "commit", "-m", "Add lib")
+
+	output, err := repo.RunWTP("add", "-b", "feature/plain")
+	framework.AssertNoError(t, err)
+	framework.AssertFalse(t, strings.Contains(output, "Initialized submodule"), "submodules are not initialized")
+
+	repo.WriteConfig("version: \"1.0\"\ndefaults:\n  submodules: init\n")
+	output, err = repo.RunWTP("add", "-b", "feature/shared")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output,
+		"Initialized submodule libs/lib (sharing objects with the main worktree)")
+
+	worktreesDir := env.TmpDir() + "/worktrees"
+	framework.AssertTrue(t, env.FileExists(worktreesDir+"/feature/shared/libs/lib/README.md"),
+		"submodule is checked out")
+	framework.AssertFalse(t, env.FileExists(worktreesDir+"/feature/plain/libs/lib/README.md"),
+		"submodules stay uninitialized by default")
+
+	output, err = repo.RunWTP("add", "-b", "feature/none", "--submodules", "none")
+	framework.AssertNoError(t, err)
+	framework.AssertFalse(t, strings.Contains(output, "Initialized submodule"), "submodules are not initialized")
+
+	output, err = repo.RunWTP("add", "-b", "feature/bad", "--submodules", "all")
+	framework.AssertError(t, err)
+	framework.AssertOutputContains(t, output, "invalid submodules mode 'all'")
+}
+
+func TestWorktreeAddSparse(t *testing.T) {
+	env := framework.NewTestEnvironment(t)
+	defer env.Cleanup()
+
+	repo := env.CreateTestRepo("sparse-monorepo")
+	repo.CommitFile("apps/web/index.html", "web", "Add web app")
+	repo.CommitFile("apps/api/main.go", "package main", "Add api")
+	repo.WriteConfig("version: \"1.0\"\nsparse_profiles:\n  web:\n    - apps/web\n")
+
+	output, err := repo.RunWTP("add", "-b", "feature/web", "--sparse", "web")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "Checked out sparse profile 'web': apps/web")
+
+	worktreePath := env.TmpDir() + "/worktrees/feature/web"
+	framework.AssertTrue(t, env.FileExists(worktreePath+"/apps/web/index.html"), "profile directories are checked out")
+	framework.AssertTrue(t, env.FileExists(worktreePath+"/README.md"), "files at the root are checked out")
+	framework.AssertFalse(t, env.FileExists(worktreePath+"/apps/api/main.go"), "other directories are left out")
+
+	output, err = repo.RunWTP("list")
+	framework.AssertNoError(t, err)
+	framework.AssertOutputContains(t, output, "sparse: web")
+
+	output, err = repo.RunWTP("add", "-b", "feature/mobile", "--sparse", "mobile")
+	framework.AssertError(t, err)
+	framework.AssertOutputContains(t, output, "sparse profile 'mobile' is not defined")
 }